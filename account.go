@@ -1,6 +1,7 @@
 package udnssdk
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -45,8 +46,13 @@ func AccountsURI() string {
 
 // Select requests all Accounts of user
 func (s *AccountsService) Select() ([]Account, *http.Response, error) {
+	return s.SelectContext(context.Background())
+}
+
+// SelectContext is Select with a context.Context
+func (s *AccountsService) SelectContext(ctx context.Context) ([]Account, *http.Response, error) {
 	var ald AccountListDTO
-	res, err := s.client.get(AccountsURI(), &ald)
+	res, err := s.client.get(ctx, AccountsURI(), &ald)
 
 	accts := []Account{}
 	for _, t := range ald.Accounts {
@@ -57,12 +63,22 @@ func (s *AccountsService) Select() ([]Account, *http.Response, error) {
 
 // Find requests an Account by AccountKey
 func (s *AccountsService) Find(k AccountKey) (Account, *http.Response, error) {
+	return s.FindContext(context.Background(), k)
+}
+
+// FindContext is Find with a context.Context
+func (s *AccountsService) FindContext(ctx context.Context, k AccountKey) (Account, *http.Response, error) {
 	var t Account
-	res, err := s.client.get(k.URI(), &t)
+	res, err := s.client.get(ctx, k.URI(), &t)
 	return t, res, err
 }
 
 // Delete requests deletion of an Account by AccountKey
 func (s *AccountsService) Delete(k AccountKey) (*http.Response, error) {
-	return s.client.delete(k.URI(), nil)
+	return s.DeleteContext(context.Background(), k)
+}
+
+// DeleteContext is Delete with a context.Context
+func (s *AccountsService) DeleteContext(ctx context.Context, k AccountKey) (*http.Response, error) {
+	return s.client.delete(ctx, k.URI(), nil)
 }
@@ -1,7 +1,7 @@
 package udnssdk
 
 import (
-	"log"
+	"context"
 	"net/http"
 	"time"
 )
@@ -42,46 +42,58 @@ type ProbeAlertDataListDTO struct {
 
 // Select returns all probe alerts with a RRSetKey
 func (s *AlertsService) Select(k RRSetKey) ([]ProbeAlertDataDTO, error) {
-	// TODO: Sane Configuration for timeouts / retries
-	maxerrs := 5
-	waittime := 5 * time.Second
+	return s.SelectContext(context.Background(), k)
+}
 
-	// init accumulators
-	as := []ProbeAlertDataDTO{}
-	offset := 0
-	errcnt := 0
-
-	for {
-		reqAlerts, ri, res, err := s.SelectWithOffset(k, offset)
-		if err != nil {
-			if res != nil && res.StatusCode >= 500 {
-				errcnt = errcnt + 1
-				if errcnt < maxerrs {
-					time.Sleep(waittime)
-					continue
-				}
-			}
-			return as, err
-		}
-
-		log.Printf("ResultInfo: %+v\n", ri)
-		for _, a := range reqAlerts {
-			as = append(as, a)
-		}
-		if ri.ReturnedCount+ri.Offset >= ri.TotalCount {
-			return as, nil
-		}
-		offset = ri.ReturnedCount + ri.Offset
-		continue
-	}
+// SelectContext is Select with a context.Context
+func (s *AlertsService) SelectContext(ctx context.Context, k RRSetKey) ([]ProbeAlertDataDTO, error) {
+	return NewPager(s.client, func(ctx context.Context, _ QueryInfo, offset int) ([]ProbeAlertDataDTO, ResultInfo, *http.Response, error) {
+		return s.SelectWithOffsetContext(ctx, k, offset)
+	}).Collect(ctx)
 }
 
 // SelectWithOffset returns the probe alerts with a RRSetKey, accepting an offset
 func (s *AlertsService) SelectWithOffset(k RRSetKey, offset int) ([]ProbeAlertDataDTO, ResultInfo, *http.Response, error) {
+	return s.SelectWithOffsetContext(context.Background(), k, offset)
+}
+
+// SelectWithOffsetContext is SelectWithOffset with a context.Context
+func (s *AlertsService) SelectWithOffsetContext(ctx context.Context, k RRSetKey, offset int) ([]ProbeAlertDataDTO, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, k.AlertsQueryURI(offset))
+}
+
+// SelectQuery returns all probe alerts with a RRSetKey matching q
+func (s *AlertsService) SelectQuery(k RRSetKey, q Query) ([]ProbeAlertDataDTO, error) {
+	return s.SelectQueryContext(context.Background(), k, q)
+}
+
+// SelectQueryContext is SelectQuery with a context.Context
+func (s *AlertsService) SelectQueryContext(ctx context.Context, k RRSetKey, q Query) ([]ProbeAlertDataDTO, error) {
+	return s.Pager(k, q).Collect(ctx)
+}
+
+// SelectQueryWithOffset returns the probe alerts with a RRSetKey matching q, accepting an offset
+func (s *AlertsService) SelectQueryWithOffset(k RRSetKey, q Query, offset int) ([]ProbeAlertDataDTO, ResultInfo, *http.Response, error) {
+	return s.SelectQueryWithOffsetContext(context.Background(), k, q, offset)
+}
+
+// SelectQueryWithOffsetContext is SelectQueryWithOffset with a context.Context
+func (s *AlertsService) SelectQueryWithOffsetContext(ctx context.Context, k RRSetKey, q Query, offset int) ([]ProbeAlertDataDTO, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, q.URI(k.AlertsURI(), offset))
+}
+
+// Pager returns a Pager over the probe alerts of k matching q
+func (s *AlertsService) Pager(k RRSetKey, q Query) *Pager[ProbeAlertDataDTO] {
+	return newQueryPager(s.client, q, func(ctx context.Context, q Query, offset int) ([]ProbeAlertDataDTO, ResultInfo, *http.Response, error) {
+		return s.SelectQueryWithOffsetContext(ctx, k, q, offset)
+	})
+}
+
+// selectURI requests a page of probe alerts from uri
+func (s *AlertsService) selectURI(ctx context.Context, uri string) ([]ProbeAlertDataDTO, ResultInfo, *http.Response, error) {
 	var ald ProbeAlertDataListDTO
 
-	uri := k.AlertsQueryURI(offset)
-	res, err := s.client.get(uri, &ald)
+	res, err := s.client.get(ctx, uri, &ald)
 
 	as := []ProbeAlertDataDTO{}
 	for _, a := range ald.Alerts {
@@ -0,0 +1,264 @@
+package udnssdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// MaxBatchSize is the largest number of requests UltraDNS accepts in a single batch
+const MaxBatchSize = 100
+
+// BatchRequest is one request of a batch, as sent to the batch endpoint
+type BatchRequest struct {
+	Method string      `json:"method"`
+	URI    string      `json:"uri"`
+	Body   interface{} `json:"body,omitempty"`
+}
+
+// BatchResponse is the response to one BatchRequest
+type BatchResponse struct {
+	Code int             `json:"code"`
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchOp is an operation queued on a Batch. Its Response and Err are set once the Batch is submitted,
+// or Err as soon as it is queued if it fails the validation the Client would run before sending it.
+type BatchOp struct {
+	Method  string
+	Path    string
+	Payload interface{}
+
+	// Response is the API's response to the operation
+	Response *BatchResponse
+	// Err is the error Client.Do would have returned for the operation
+	Err error
+
+	v        interface{}
+	rejected bool
+	done     bool
+}
+
+// Batch queues operations to be sent through the batch endpoint, instead of one request each
+type Batch struct {
+	client *Client
+	// Size is the number of operations sent per batch request.
+	// Values of zero or less, or above MaxBatchSize, use MaxBatchSize.
+	Size int
+
+	ops []*BatchOp
+}
+
+// BatchError reports the operations of a submitted Batch that failed
+type BatchError struct {
+	Failed []*BatchOp
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d batch operations failed; first: %s %s: %v", len(e.Failed), e.Failed[0].Method, e.Failed[0].Path, e.Failed[0].Err)
+}
+
+// Unwrap returns the errors of the failed operations
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, op := range e.Failed {
+		errs[i] = op.Err
+	}
+	return errs
+}
+
+// NewBatch returns an empty Batch
+func (c *Client) NewBatch() *Batch {
+	return &Batch{client: c}
+}
+
+// Len returns the number of queued operations
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+// Ops returns the queued operations
+func (b *Batch) Ops() []*BatchOp {
+	return b.ops
+}
+
+// Add queues a request for path, as Client.Do would send it. Once the Batch is submitted,
+// a successful response is JSON decoded into v, if it is not nil.
+func (b *Batch) Add(method, path string, payload, v interface{}) *BatchOp {
+	op := &BatchOp{Method: method, Path: path, Payload: payload, v: v}
+	b.ops = append(b.ops, op)
+	return op
+}
+
+// reject marks op as failing validation with err, so that it is not sent
+func (op *BatchOp) reject(err error) *BatchOp {
+	if err != nil {
+		op.Err, op.rejected = err, true
+	}
+	return op
+}
+
+// CreateRRSet queues the creation of an RRSet, validating it as RRSets.Create would
+func (b *Batch) CreateRRSet(k RRSetKey, rrset RRSet) *BatchOp {
+	return b.Add("POST", k.URI(), rrset, nil).reject(b.validateRRSet(k, rrset))
+}
+
+// UpdateRRSet queues the update of an RRSet, validating it as RRSets.Update would
+func (b *Batch) UpdateRRSet(k RRSetKey, rrset RRSet) *BatchOp {
+	return b.Add("PUT", k.URI(), rrset, nil).reject(b.validateRRSet(k, rrset))
+}
+
+func (b *Batch) validateRRSet(k RRSetKey, rrset RRSet) error {
+	if !b.client.ValidateRRSets {
+		return nil
+	}
+	return k.validate(rrset)
+}
+
+// MergePatchRRSet queues a merge-style partial update of an RRSet
+func (b *Batch) MergePatchRRSet(k RRSetKey, p RRSetPatch) *BatchOp {
+	return b.Add("PATCH", k.URI(), p, nil)
+}
+
+// DeleteRRSet queues the deletion of an RRSet
+func (b *Batch) DeleteRRSet(k RRSetKey) *BatchOp {
+	return b.Add("DELETE", k.URI(), nil, nil)
+}
+
+// CreateProbe queues the creation of a probe on an RRSet, validating it as Probes.Create would
+func (b *Batch) CreateProbe(k RRSetKey, p ProbeInfoDTO) *BatchOp {
+	return b.Add("POST", k.ProbesURI(), p, nil).reject(b.client.Probes.validate(p))
+}
+
+// UpdateProbe queues the update of a probe, validating it as Probes.Update would
+func (b *Batch) UpdateProbe(k ProbeKey, p ProbeInfoDTO) *BatchOp {
+	return b.Add("PUT", k.URI(), p, nil).reject(b.client.Probes.validate(p))
+}
+
+// DeleteProbe queues the deletion of a probe
+func (b *Batch) DeleteProbe(k ProbeKey) *BatchOp {
+	return b.Add("DELETE", k.URI(), nil, nil)
+}
+
+// CreateNotification queues the creation of a notification
+func (b *Batch) CreateNotification(k NotificationKey, n NotificationDTO) *BatchOp {
+	return b.Add("POST", k.URI(), n, nil)
+}
+
+// UpdateNotification queues the update of a notification
+func (b *Batch) UpdateNotification(k NotificationKey, n NotificationDTO) *BatchOp {
+	return b.Add("PUT", k.URI(), n, nil)
+}
+
+// DeleteNotification queues the deletion of a notification
+func (b *Batch) DeleteNotification(k NotificationKey) *BatchOp {
+	return b.Add("DELETE", k.URI(), nil, nil)
+}
+
+// Submit sends the queued operations in batches of Size, waiting on the task of each,
+// and sets the Response and Err of every operation. Operations rejected when queued are not sent,
+// nor are those that succeeded in an earlier Submit, so that submitting again after a failure
+// only sends the operations that failed or were not sent.
+// A failed batch request stops the submission; the operations it did not complete get its error.
+// If any operation failed, rejected or in a completed batch, a *BatchError is returned,
+// joined with the error of the failed batch request if there is one.
+func (b *Batch) Submit() error {
+	return b.SubmitContext(context.Background())
+}
+
+// SubmitContext is Submit with a context.Context.
+// The batch tasks are always waited on, even if the TaskWaiter in effect is Async.
+func (b *Batch) SubmitContext(ctx context.Context) error {
+	w := b.client.taskWaiter(ctx)
+	w.Async = false
+	ctx = ContextWithTaskWaiter(ctx, w)
+
+	size := b.Size
+	if size <= 0 || size > MaxBatchSize {
+		size = MaxBatchSize
+	}
+
+	ops := []*BatchOp{}
+	for _, op := range b.ops {
+		if !op.rejected && !op.done {
+			ops = append(ops, op)
+		}
+	}
+
+	var chunkErr error
+	completed := map[*BatchOp]bool{}
+	for start := 0; start < len(ops); start += size {
+		end := start + size
+		if end > len(ops) {
+			end = len(ops)
+		}
+		if chunkErr = b.submitChunk(ctx, ops[start:end]); chunkErr != nil {
+			for _, op := range ops[start:] {
+				op.Err = fmt.Errorf("batch not completed: %w", chunkErr)
+			}
+			break
+		}
+		for _, op := range ops[start:end] {
+			completed[op] = true
+			op.done = op.Err == nil
+		}
+	}
+
+	res := &BatchError{}
+	for _, op := range b.ops {
+		if op.Err != nil && (op.rejected || completed[op]) {
+			res.Failed = append(res.Failed, op)
+		}
+	}
+	switch {
+	case len(res.Failed) == 0:
+		return chunkErr
+	case chunkErr != nil:
+		return errors.Join(chunkErr, res)
+	}
+	return res
+}
+
+// submitChunk sends ops as a single batch request and maps the responses back to them
+func (b *Batch) submitChunk(ctx context.Context, ops []*BatchOp) error {
+	reqs := make([]BatchRequest, len(ops))
+	for i, op := range ops {
+		reqs[i] = BatchRequest{Method: op.Method, URI: fmt.Sprintf("/%s/%s", apiVersion, op.Path), Body: op.Payload}
+	}
+
+	resps := []BatchResponse{}
+	if _, err := b.client.post(ctx, "batch", reqs, &resps); err != nil {
+		return err
+	}
+	if len(resps) != len(ops) {
+		return fmt.Errorf("batch returned %d responses for %d requests", len(resps), len(ops))
+	}
+
+	for i, op := range ops {
+		op.Response = &resps[i]
+		op.Err = b.client.batchResponseError(ctx, op, resps[i])
+		if op.Err == nil && op.v != nil && len(resps[i].Body) != 0 {
+			op.Err = json.Unmarshal(resps[i].Body, op.v)
+		}
+	}
+	return nil
+}
+
+// batchResponseError returns the error of an operation's response, as CheckResponse would for a request of its own
+func (c *Client) batchResponseError(ctx context.Context, op *BatchOp, br BatchResponse) error {
+	req, err := c.NewRequestContext(ctx, op.Method, op.Path, nil)
+	if err != nil {
+		return err
+	}
+	return CheckResponse(&http.Response{
+		Status:     fmt.Sprintf("%d %s", br.Code, http.StatusText(br.Code)),
+		StatusCode: br.Code,
+		Body:       ioutil.NopCloser(bytes.NewReader(br.Body)),
+		Request:    req,
+	})
+}
@@ -0,0 +1,173 @@
+package udnssdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_Batch_Submit(t *testing.T) {
+	var sizes []int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v1/batch" {
+			t.Errorf("request: %s %s", r.Method, r.URL.Path)
+		}
+		var reqs []BatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatal(err)
+		}
+		sizes = append(sizes, len(reqs))
+		resps := []BatchResponse{}
+		for _, br := range reqs {
+			switch {
+			case br.URI == "/v1/zones/example.com./rrsets/A/dup.example.com.":
+				resps = append(resps, BatchResponse{Code: 400, Body: json.RawMessage(`[{"errorCode":2111,"errorMessage":"Resource Record of type 1 with these attributes already exists in the system."}]`)})
+			case br.Method == "GET":
+				resps = append(resps, BatchResponse{Code: 200, Body: json.RawMessage(`{"ownerName":"www.example.com.","rrtype":"A (1)","ttl":300,"rdata":["192.0.2.1"]}`)})
+			default:
+				resps = append(resps, BatchResponse{Code: 201, Body: json.RawMessage(`{"message":"Successful"}`)})
+			}
+		}
+		mess, _ := json.Marshal(resps)
+		fmt.Fprintln(w, string(mess))
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	b := testClient.NewBatch()
+	b.Size = 2
+
+	zone := "example.com."
+	create := b.CreateRRSet(RRSetKey{Zone: zone, Type: "A", Name: "www.example.com."}, RRSet{TTL: 300, RData: []string{"192.0.2.1"}})
+	dup := b.CreateRRSet(RRSetKey{Zone: zone, Type: "A", Name: "dup.example.com."}, RRSet{TTL: 300, RData: []string{"192.0.2.1"}})
+	var rr RRSet
+	get := b.Add("GET", RRSetKey{Zone: zone, Type: "A", Name: "www.example.com."}.URI(), nil, &rr)
+	del := b.DeleteNotification(NotificationKey{Zone: zone, Type: "A", Name: "www.example.com.", Email: "ops@example.com"})
+	probe := b.CreateProbe(RRSetKey{Zone: zone, Type: "A", Name: "www.example.com."}, NewPingProbe(ProbeIntervalOneMinute, []ProbeAgent{ProbeAgentDallas}, 1, PingProbeDetailsDTO{Limits: map[string]ProbeDetailsLimitDTO{"run": {Fail: 10}}}))
+
+	err := b.Submit()
+	var be *BatchError
+	if !errors.As(err, &be) || len(be.Failed) != 1 || be.Failed[0] != dup {
+		t.Fatalf("Submit: %v", err)
+	}
+	if !IsAlreadyExists(err) || !IsAlreadyExists(dup.Err) {
+		t.Errorf("dup.Err: %v", dup.Err)
+	}
+	for _, op := range []*BatchOp{create, get, del, probe} {
+		if op.Err != nil || op.Response == nil {
+			t.Errorf("%s %s: %+v", op.Method, op.Path, op)
+		}
+	}
+	if rr.OwnerName != "www.example.com." || rr.TTL != 300 {
+		t.Errorf("decoded RRSet: %+v", rr)
+	}
+	if fmt.Sprint(sizes) != "[2 2 1]" {
+		t.Errorf("batch sizes: %v", sizes)
+	}
+}
+
+func Test_Batch_Submit_RequestFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintln(w, `[{"errorCode":49001,"errorMessage":"Invalid batch."}]`)
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	b := testClient.NewBatch()
+	op := b.DeleteRRSet(RRSetKey{Zone: "example.com.", Type: "A", Name: "www.example.com."})
+
+	err := b.Submit()
+	if !IsValidation(err) {
+		t.Errorf("Submit: %v", err)
+	}
+	if !IsValidation(op.Err) || op.Response != nil {
+		t.Errorf("op: %+v", op)
+	}
+}
+
+func Test_Batch_Submit_Validation(t *testing.T) {
+	var sent []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqs []BatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatal(err)
+		}
+		resps := []BatchResponse{}
+		for _, br := range reqs {
+			sent = append(sent, br.URI)
+			resps = append(resps, BatchResponse{Code: 201, Body: json.RawMessage(`{"message":"Successful"}`)})
+		}
+		mess, _ := json.Marshal(resps)
+		fmt.Fprintln(w, string(mess))
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	testClient.ValidateRRSets = true
+	b := testClient.NewBatch()
+
+	zone := "example.com."
+	bad := b.CreateRRSet(RRSetKey{Zone: zone, Type: "A", Name: "bad.example.com."}, RRSet{TTL: 300, RData: []string{"not-an-ip"}})
+	good := b.UpdateRRSet(RRSetKey{Zone: zone, Type: "A", Name: "www.example.com."}, RRSet{TTL: 300, RData: []string{"192.0.2.1"}})
+	if !IsValidation(bad.Err) || good.Err != nil {
+		t.Fatalf("queued: bad %v, good %v", bad.Err, good.Err)
+	}
+
+	err := b.Submit()
+	var be *BatchError
+	if !errors.As(err, &be) || len(be.Failed) != 1 || be.Failed[0] != bad || !IsValidation(err) {
+		t.Fatalf("Submit: %v", err)
+	}
+	if bad.Response != nil || good.Err != nil || good.Response == nil {
+		t.Errorf("bad %+v, good %+v", bad, good)
+	}
+	if fmt.Sprint(sent) != "[/v1/zones/example.com./rrsets/A/www.example.com.]" {
+		t.Errorf("sent: %v", sent)
+	}
+
+	k := RRSetKey{Zone: zone, Type: "A", Name: "pool.example.com."}
+	probe := NewPingProbe(ProbeIntervalOneMinute, []ProbeAgent{ProbeAgentDallas}, 2, PingProbeDetailsDTO{})
+	if op := testClient.NewBatch().CreateProbe(k, probe); op.Err != nil {
+		t.Errorf("CreateProbe without validation: %v", op.Err)
+	}
+	testClient.ValidateProbes = true
+	if op := testClient.NewBatch().CreateProbe(k, probe); !IsValidation(op.Err) {
+		t.Errorf("CreateProbe: %v, want a validation error", op.Err)
+	}
+}
+
+func Test_Batch_Submit_LaterChunkFailure(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls > 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintln(w, `[{"errorCode":50000,"errorMessage":"Internal error."}]`)
+			return
+		}
+		fmt.Fprintln(w, `[{"code":404,"body":[{"errorCode":70002,"errorMessage":"Data not found."}]}]`)
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	b := testClient.NewBatch()
+	b.Size = 1
+	first := b.DeleteRRSet(RRSetKey{Zone: "example.com.", Type: "A", Name: "gone.example.com."})
+	second := b.DeleteRRSet(RRSetKey{Zone: "example.com.", Type: "A", Name: "www.example.com."})
+
+	err := b.Submit()
+	var be *BatchError
+	if !errors.As(err, &be) || len(be.Failed) != 1 || be.Failed[0] != first {
+		t.Fatalf("Submit: %v", err)
+	}
+	if !IsNotFound(err) || !IsNotFound(first.Err) {
+		t.Errorf("first: %v", first.Err)
+	}
+	if second.Err == nil || second.Response != nil {
+		t.Errorf("second: %+v", second)
+	}
+}
@@ -19,6 +19,7 @@ var logLevel string
 var zone string
 var domain string
 var typ string
+var format string
 
 func init() {
 	flag.StringVar(&username, "username", os.Getenv("ULTRADNS_USERNAME"), "ultradns username")
@@ -28,6 +29,7 @@ func init() {
 	flag.StringVar(&zone, "zone", "", "dns zone")
 	flag.StringVar(&domain, "domain", "", "dns domain")
 	flag.StringVar(&typ, "type", "A", "dns type")
+	flag.StringVar(&format, "format", "json", "output format: json, or zone to export the whole zone as a BIND zone file")
 }
 
 func main() {
@@ -46,7 +48,7 @@ func main() {
 		fmt.Println("no zone provided. Set with parameter -zone=example.com.")
 		os.Exit(1)
 	}
-	if domain == "" {
+	if domain == "" && format != "zone" {
 		fmt.Println("no domain provided. Set with parameter -domain=foo")
 		os.Exit(1)
 	}
@@ -58,12 +60,23 @@ func main() {
 	}
 	log.SetOutput(filter)
 
-	client, err := udnssdk.NewClient(username, password, baseURL)
+	client, err := udnssdk.NewClientWithOptions(
+		udnssdk.WithCredentials(username, password),
+		udnssdk.WithBaseURL(baseURL),
+		udnssdk.WithLogger(udnssdk.NewStdLogger(nil)),
+	)
 
 	if err != nil {
 		log.Fatalf("Error setting up client: %s", err)
 	}
 
+	if format == "zone" {
+		if err := client.RRSets.Export(os.Stdout, zone); err != nil {
+			log.Fatalf("Error exporting zone: %s", err)
+		}
+		return
+	}
+
 	k := udnssdk.RRSetKey{
 		Zone: zone,
 		Type: typ,
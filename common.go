@@ -1,17 +1,29 @@
 package udnssdk
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+	"time"
+)
 
 // GetResultByURI just requests a URI
 func (c *Client) GetResultByURI(uri string) (*http.Response, error) {
-	req, err := c.NewRequest("GET", uri, nil)
-	if err != nil {
-		return nil, err
-	}
-	res, err := c.HTTPClient.Do(req)
+	return c.GetResultByURIContext(context.Background(), uri)
+}
+
+// GetResultByURIContext is GetResultByURI with a context.Context
+func (c *Client) GetResultByURIContext(ctx context.Context, uri string) (*http.Response, error) {
+	return c.send(ctx, "GET", uri, nil)
+}
 
-	if err != nil {
-		return res, err
+// sleepContext waits for d to elapse, returning early with the context's error if ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
 	}
-	return res, err
 }
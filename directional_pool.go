@@ -1,10 +1,10 @@
 package udnssdk
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"net/http"
-	"time"
+	"sort"
 )
 
 // DirectionalPoolsService manages 'account level' 'geo' and 'ip' groups for directional-pools
@@ -12,12 +12,30 @@ type DirectionalPoolsService struct {
 	client *Client
 }
 
-// DirectionalPool wraps an account-level directional-groups response from a index request
+// Here lie the types of directional groups
+const (
+	GeoDirectionalPoolType = "geo"
+	IPDirectionalPoolType  = "ip"
+)
+
+// DirectionalPool is an account-level directional group of either type: Codes are set for geo groups, IPs for ip groups
 type DirectionalPool struct {
-	DirectionalPoolID         string `json:"taskId"`
-	DirectionalPoolStatusCode string `json:"taskStatusCode"`
-	Message                   string `json:"message"`
-	ResultURI                 string `json:"resultUri"`
+	// Type is GeoDirectionalPoolType or IPDirectionalPoolType
+	Type        string      `json:"type"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Codes       []string    `json:"codes,omitempty"`
+	IPs         []IPAddrDTO `json:"ips,omitempty"`
+}
+
+// DirectionalPool returns g as a DirectionalPool
+func (g AccountLevelGeoDirectionalGroupDTO) DirectionalPool() DirectionalPool {
+	return DirectionalPool{Type: GeoDirectionalPoolType, Name: g.Name, Description: g.Description, Codes: g.Codes}
+}
+
+// DirectionalPool returns g as a DirectionalPool
+func (g AccountLevelIPDirectionalGroupDTO) DirectionalPool() DirectionalPool {
+	return DirectionalPool{Type: IPDirectionalPoolType, Name: g.Name, Description: g.Description, IPs: g.IPs}
 }
 
 // AccountLevelGeoDirectionalGroupDTO wraps an account-level, geo directonal-group response
@@ -42,13 +60,6 @@ type AccountLevelIPDirectionalGroupDTO struct {
 	IPs         []IPAddrDTO `json:"ips"`
 }
 
-// DirectionalPoolListDTO wraps a list of account-level directional-groups response from a index request
-type DirectionalPoolListDTO struct {
-	DirectionalPools []DirectionalPool `json:"tasks"`
-	Queryinfo        QueryInfo         `json:"queryInfo"`
-	Resultinfo       ResultInfo        `json:"resultInfo"`
-}
-
 // AccountLevelGeoDirectionalGroupListDTO wraps a list of account-level, geo directional-groups response from a index request
 type AccountLevelGeoDirectionalGroupListDTO struct {
 	AccountName string                               `json:"zoneName"`
@@ -75,6 +86,59 @@ func (s *DirectionalPoolsService) IPs() *IPDirectionalPoolsService {
 	return &IPDirectionalPoolsService{client: s.client}
 }
 
+// Select requests the geo and ip directional groups of the account of k matching q; only those of k.Type if it is set.
+// The groups of both types are listed together, geo groups first, or ordered by NAME or TYPE if q.Sort is set, and
+// reversed if q.Reverse is. q.Limit is the page size of the requests for each type, and does not cap the groups returned.
+func (s *DirectionalPoolsService) Select(k DirectionalPoolKey, q Query) ([]DirectionalPool, error) {
+	return s.SelectContext(context.Background(), k, q)
+}
+
+// SelectContext is Select with a context.Context
+func (s *DirectionalPoolsService) SelectContext(ctx context.Context, k DirectionalPoolKey, q Query) ([]DirectionalPool, error) {
+	pools := []DirectionalPool{}
+	less, ok := directionalPoolSorts[q.Sort]
+	if !ok {
+		return pools, fmt.Errorf("cannot sort directional groups by %q", q.Sort)
+	}
+	// the groups of both types are ordered together below
+	reverse := q.Reverse
+	q.Sort, q.Reverse = "", false
+	if k.Type == "" || k.Type == GeoDirectionalPoolType {
+		groups, err := s.Geos().SelectQueryContext(ctx, GeoDirectionalPoolKey{Account: k.Account}, q)
+		if err != nil {
+			return pools, err
+		}
+		for _, g := range groups {
+			pools = append(pools, g.DirectionalPool())
+		}
+	}
+	if k.Type == "" || k.Type == IPDirectionalPoolType {
+		groups, err := s.IPs().SelectQueryContext(ctx, IPDirectionalPoolKey{Account: k.Account}, q)
+		if err != nil {
+			return pools, err
+		}
+		for _, g := range groups {
+			pools = append(pools, g.DirectionalPool())
+		}
+	}
+	if less != nil {
+		sort.SliceStable(pools, func(i, j int) bool { return less(pools[i], pools[j]) })
+	}
+	if reverse {
+		for i, j := 0, len(pools)-1; i < j; i, j = i+1, j-1 {
+			pools[i], pools[j] = pools[j], pools[i]
+		}
+	}
+	return pools, nil
+}
+
+// directionalPoolSorts orders DirectionalPoolsService.Select by each Query.Sort it accepts
+var directionalPoolSorts = map[string]func(a, b DirectionalPool) bool{
+	"":     nil,
+	"NAME": func(a, b DirectionalPool) bool { return a.Name < b.Name },
+	"TYPE": func(a, b DirectionalPool) bool { return a.Type < b.Type },
+}
+
 // DirectionalPoolKey collects the identifiers of a DirectionalPool
 type DirectionalPoolKey struct {
 	Account AccountKey
@@ -113,7 +177,7 @@ type GeoDirectionalPoolKey struct {
 func (k GeoDirectionalPoolKey) DirectionalPoolKey() DirectionalPoolKey {
 	return DirectionalPoolKey{
 		Account: k.Account,
-		Type:    "geo",
+		Type:    GeoDirectionalPoolType,
 		Name:    k.Name,
 	}
 }
@@ -135,45 +199,58 @@ type GeoDirectionalPoolsService struct {
 
 // Select requests all geo directional-pools, by query and account, providing pagination and error handling
 func (s *GeoDirectionalPoolsService) Select(k GeoDirectionalPoolKey, query string) ([]AccountLevelGeoDirectionalGroupDTO, error) {
-	// TODO: Sane Configuration for timeouts / retries
-	maxerrs := 5
-	waittime := 5 * time.Second
-
-	// init accumulators
-	dtos := []AccountLevelGeoDirectionalGroupDTO{}
-	errcnt := 0
-	offset := 0
-
-	for {
-		reqDtos, ri, res, err := s.SelectWithOffset(k, query, offset)
-		if err != nil {
-			if res != nil && res.StatusCode >= 500 {
-				errcnt = errcnt + 1
-				if errcnt < maxerrs {
-					time.Sleep(waittime)
-					continue
-				}
-			}
-			return dtos, err
-		}
+	return s.SelectContext(context.Background(), k, query)
+}
 
-		log.Printf("[DEBUG] ResultInfo: %+v\n", ri)
-		for _, d := range reqDtos {
-			dtos = append(dtos, d)
-		}
-		if ri.ReturnedCount+ri.Offset >= ri.TotalCount {
-			return dtos, nil
-		}
-		offset = ri.ReturnedCount + ri.Offset
-		continue
-	}
+// SelectContext is Select with a context.Context
+func (s *GeoDirectionalPoolsService) SelectContext(ctx context.Context, k GeoDirectionalPoolKey, query string) ([]AccountLevelGeoDirectionalGroupDTO, error) {
+	return NewPager(s.client, func(ctx context.Context, _ QueryInfo, offset int) ([]AccountLevelGeoDirectionalGroupDTO, ResultInfo, *http.Response, error) {
+		return s.SelectWithOffsetContext(ctx, k, query, offset)
+	}).Collect(ctx)
 }
 
 // SelectWithOffset requests list of geo directional-pools, by query & account, and an offset, returning the directional-group, the list-metadata, the actual response, or an error
 func (s *GeoDirectionalPoolsService) SelectWithOffset(k GeoDirectionalPoolKey, query string, offset int) ([]AccountLevelGeoDirectionalGroupDTO, ResultInfo, *http.Response, error) {
+	return s.SelectWithOffsetContext(context.Background(), k, query, offset)
+}
+
+// SelectWithOffsetContext is SelectWithOffset with a context.Context
+func (s *GeoDirectionalPoolsService) SelectWithOffsetContext(ctx context.Context, k GeoDirectionalPoolKey, query string, offset int) ([]AccountLevelGeoDirectionalGroupDTO, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, k.QueryURI(query, offset))
+}
+
+// SelectQuery requests all geo directional-pools of the account of k matching q, using pagination
+func (s *GeoDirectionalPoolsService) SelectQuery(k GeoDirectionalPoolKey, q Query) ([]AccountLevelGeoDirectionalGroupDTO, error) {
+	return s.SelectQueryContext(context.Background(), k, q)
+}
+
+// SelectQueryContext is SelectQuery with a context.Context
+func (s *GeoDirectionalPoolsService) SelectQueryContext(ctx context.Context, k GeoDirectionalPoolKey, q Query) ([]AccountLevelGeoDirectionalGroupDTO, error) {
+	return s.Pager(k, q).Collect(ctx)
+}
+
+// SelectQueryWithOffset requests a page of the geo directional-pools of the account of k matching q, starting at offset
+func (s *GeoDirectionalPoolsService) SelectQueryWithOffset(k GeoDirectionalPoolKey, q Query, offset int) ([]AccountLevelGeoDirectionalGroupDTO, ResultInfo, *http.Response, error) {
+	return s.SelectQueryWithOffsetContext(context.Background(), k, q, offset)
+}
+
+// SelectQueryWithOffsetContext is SelectQueryWithOffset with a context.Context
+func (s *GeoDirectionalPoolsService) SelectQueryWithOffsetContext(ctx context.Context, k GeoDirectionalPoolKey, q Query, offset int) ([]AccountLevelGeoDirectionalGroupDTO, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, q.URI(k.URI(), offset))
+}
+
+// Pager returns a Pager over the geo directional-pools of k matching q
+func (s *GeoDirectionalPoolsService) Pager(k GeoDirectionalPoolKey, q Query) *Pager[AccountLevelGeoDirectionalGroupDTO] {
+	return newQueryPager(s.client, q, func(ctx context.Context, q Query, offset int) ([]AccountLevelGeoDirectionalGroupDTO, ResultInfo, *http.Response, error) {
+		return s.SelectQueryWithOffsetContext(ctx, k, q, offset)
+	})
+}
+
+// selectURI requests a page of geo directional-pools from uri
+func (s *GeoDirectionalPoolsService) selectURI(ctx context.Context, uri string) ([]AccountLevelGeoDirectionalGroupDTO, ResultInfo, *http.Response, error) {
 	var tld AccountLevelGeoDirectionalGroupListDTO
 
-	res, err := s.client.get(k.QueryURI(query, offset), &tld)
+	res, err := s.client.get(ctx, uri, &tld)
 
 	pis := []AccountLevelGeoDirectionalGroupDTO{}
 	for _, pi := range tld.GeoGroups {
@@ -184,24 +261,64 @@ func (s *GeoDirectionalPoolsService) SelectWithOffset(k GeoDirectionalPoolKey, q
 
 // Find requests a geo directional-pool by name & account
 func (s *GeoDirectionalPoolsService) Find(k GeoDirectionalPoolKey) (AccountLevelGeoDirectionalGroupDTO, *http.Response, error) {
+	return s.FindContext(context.Background(), k)
+}
+
+// FindContext is Find with a context.Context
+func (s *GeoDirectionalPoolsService) FindContext(ctx context.Context, k GeoDirectionalPoolKey) (AccountLevelGeoDirectionalGroupDTO, *http.Response, error) {
 	var t AccountLevelGeoDirectionalGroupDTO
-	res, err := s.client.get(k.URI(), &t)
+	res, err := s.client.get(ctx, k.URI(), &t)
 	return t, res, err
 }
 
-// Create requests creation of a DirectionalPool by DirectionalPoolKey given a directional-pool
-func (s *GeoDirectionalPoolsService) Create(k GeoDirectionalPoolKey, val interface{}) (*http.Response, error) {
-	return s.client.post(k.URI(), val, nil)
+// Create requests creation of a directional group; its Name defaults to that of k
+func (s *GeoDirectionalPoolsService) Create(k GeoDirectionalPoolKey, val AccountLevelGeoDirectionalGroupDTO) (*http.Response, error) {
+	return s.CreateContext(context.Background(), k, val)
 }
 
-// Update requests update of a DirectionalPool by DirectionalPoolKey given a directional-pool
-func (s *GeoDirectionalPoolsService) Update(k GeoDirectionalPoolKey, val interface{}) (*http.Response, error) {
-	return s.client.put(k.URI(), val, nil)
+// CreateContext is Create with a context.Context
+func (s *GeoDirectionalPoolsService) CreateContext(ctx context.Context, k GeoDirectionalPoolKey, val AccountLevelGeoDirectionalGroupDTO) (*http.Response, error) {
+	if val.Name == "" {
+		val.Name = k.Name
+	}
+	if err := s.validate(val); err != nil {
+		return nil, err
+	}
+	return s.client.post(ctx, k.URI(), val, nil)
+}
+
+// Update requests update of a directional group; its Name defaults to that of k
+func (s *GeoDirectionalPoolsService) Update(k GeoDirectionalPoolKey, val AccountLevelGeoDirectionalGroupDTO) (*http.Response, error) {
+	return s.UpdateContext(context.Background(), k, val)
+}
+
+// UpdateContext is Update with a context.Context
+func (s *GeoDirectionalPoolsService) UpdateContext(ctx context.Context, k GeoDirectionalPoolKey, val AccountLevelGeoDirectionalGroupDTO) (*http.Response, error) {
+	if val.Name == "" {
+		val.Name = k.Name
+	}
+	if err := s.validate(val); err != nil {
+		return nil, err
+	}
+	return s.client.put(ctx, k.URI(), val, nil)
+}
+
+// validate checks the codes of a geo group if the client validates them
+func (s *GeoDirectionalPoolsService) validate(g AccountLevelGeoDirectionalGroupDTO) error {
+	if !s.client.ValidateGeoGroups {
+		return nil
+	}
+	return g.Validate()
 }
 
 // Delete requests deletion of a DirectionalPool
 func (s *GeoDirectionalPoolsService) Delete(k GeoDirectionalPoolKey) (*http.Response, error) {
-	return s.client.delete(k.URI(), nil)
+	return s.DeleteContext(context.Background(), k)
+}
+
+// DeleteContext is Delete with a context.Context
+func (s *GeoDirectionalPoolsService) DeleteContext(ctx context.Context, k GeoDirectionalPoolKey) (*http.Response, error) {
+	return s.client.delete(ctx, k.URI(), nil)
 }
 
 // IPDirectionalPoolKey collects the identifiers of an DirectionalPool with type IP
@@ -214,7 +331,7 @@ type IPDirectionalPoolKey struct {
 func (k IPDirectionalPoolKey) DirectionalPoolKey() DirectionalPoolKey {
 	return DirectionalPoolKey{
 		Account: k.Account,
-		Type:    "ip",
+		Type:    IPDirectionalPoolType,
 		Name:    k.Name,
 	}
 }
@@ -236,45 +353,58 @@ type IPDirectionalPoolsService struct {
 
 // Select requests all IP directional-pools, using pagination and error handling
 func (s *IPDirectionalPoolsService) Select(k IPDirectionalPoolKey, query string) ([]AccountLevelIPDirectionalGroupDTO, error) {
-	// TODO: Sane Configuration for timeouts / retries
-	maxerrs := 5
-	waittime := 5 * time.Second
-
-	// init accumulators
-	gs := []AccountLevelIPDirectionalGroupDTO{}
-	errcnt := 0
-	offset := 0
-
-	for {
-		reqIPGroups, ri, res, err := s.SelectWithOffset(k, query, offset)
-		if err != nil {
-			if res != nil && res.StatusCode >= 500 {
-				errcnt = errcnt + 1
-				if errcnt < maxerrs {
-					time.Sleep(waittime)
-					continue
-				}
-			}
-			return gs, err
-		}
+	return s.SelectContext(context.Background(), k, query)
+}
 
-		log.Printf("ResultInfo: %+v\n", ri)
-		for _, g := range reqIPGroups {
-			gs = append(gs, g)
-		}
-		if ri.ReturnedCount+ri.Offset >= ri.TotalCount {
-			return gs, nil
-		}
-		offset = ri.ReturnedCount + ri.Offset
-		continue
-	}
+// SelectContext is Select with a context.Context
+func (s *IPDirectionalPoolsService) SelectContext(ctx context.Context, k IPDirectionalPoolKey, query string) ([]AccountLevelIPDirectionalGroupDTO, error) {
+	return NewPager(s.client, func(ctx context.Context, _ QueryInfo, offset int) ([]AccountLevelIPDirectionalGroupDTO, ResultInfo, *http.Response, error) {
+		return s.SelectWithOffsetContext(ctx, k, query, offset)
+	}).Collect(ctx)
 }
 
 // SelectWithOffset requests all IP directional-pools, by query & account, and an offset, returning the list of IP groups, list metadata & the actual response, or an error
 func (s *IPDirectionalPoolsService) SelectWithOffset(k IPDirectionalPoolKey, query string, offset int) ([]AccountLevelIPDirectionalGroupDTO, ResultInfo, *http.Response, error) {
+	return s.SelectWithOffsetContext(context.Background(), k, query, offset)
+}
+
+// SelectWithOffsetContext is SelectWithOffset with a context.Context
+func (s *IPDirectionalPoolsService) SelectWithOffsetContext(ctx context.Context, k IPDirectionalPoolKey, query string, offset int) ([]AccountLevelIPDirectionalGroupDTO, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, k.QueryURI(query, offset))
+}
+
+// SelectQuery requests all IP directional-pools of the account of k matching q, using pagination
+func (s *IPDirectionalPoolsService) SelectQuery(k IPDirectionalPoolKey, q Query) ([]AccountLevelIPDirectionalGroupDTO, error) {
+	return s.SelectQueryContext(context.Background(), k, q)
+}
+
+// SelectQueryContext is SelectQuery with a context.Context
+func (s *IPDirectionalPoolsService) SelectQueryContext(ctx context.Context, k IPDirectionalPoolKey, q Query) ([]AccountLevelIPDirectionalGroupDTO, error) {
+	return s.Pager(k, q).Collect(ctx)
+}
+
+// SelectQueryWithOffset requests a page of the IP directional-pools of the account of k matching q, starting at offset
+func (s *IPDirectionalPoolsService) SelectQueryWithOffset(k IPDirectionalPoolKey, q Query, offset int) ([]AccountLevelIPDirectionalGroupDTO, ResultInfo, *http.Response, error) {
+	return s.SelectQueryWithOffsetContext(context.Background(), k, q, offset)
+}
+
+// SelectQueryWithOffsetContext is SelectQueryWithOffset with a context.Context
+func (s *IPDirectionalPoolsService) SelectQueryWithOffsetContext(ctx context.Context, k IPDirectionalPoolKey, q Query, offset int) ([]AccountLevelIPDirectionalGroupDTO, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, q.URI(k.URI(), offset))
+}
+
+// Pager returns a Pager over the IP directional-pools of k matching q
+func (s *IPDirectionalPoolsService) Pager(k IPDirectionalPoolKey, q Query) *Pager[AccountLevelIPDirectionalGroupDTO] {
+	return newQueryPager(s.client, q, func(ctx context.Context, q Query, offset int) ([]AccountLevelIPDirectionalGroupDTO, ResultInfo, *http.Response, error) {
+		return s.SelectQueryWithOffsetContext(ctx, k, q, offset)
+	})
+}
+
+// selectURI requests a page of IP directional-pools from uri
+func (s *IPDirectionalPoolsService) selectURI(ctx context.Context, uri string) ([]AccountLevelIPDirectionalGroupDTO, ResultInfo, *http.Response, error) {
 	var tld AccountLevelIPDirectionalGroupListDTO
 
-	res, err := s.client.get(k.QueryURI(query, offset), &tld)
+	res, err := s.client.get(ctx, uri, &tld)
 
 	pis := []AccountLevelIPDirectionalGroupDTO{}
 	for _, pi := range tld.IPGroups {
@@ -286,22 +416,62 @@ func (s *IPDirectionalPoolsService) SelectWithOffset(k IPDirectionalPoolKey, que
 
 // Find requests a directional-pool by name & account
 func (s *IPDirectionalPoolsService) Find(k IPDirectionalPoolKey) (AccountLevelIPDirectionalGroupDTO, *http.Response, error) {
+	return s.FindContext(context.Background(), k)
+}
+
+// FindContext is Find with a context.Context
+func (s *IPDirectionalPoolsService) FindContext(ctx context.Context, k IPDirectionalPoolKey) (AccountLevelIPDirectionalGroupDTO, *http.Response, error) {
 	var t AccountLevelIPDirectionalGroupDTO
-	res, err := s.client.get(k.URI(), &t)
+	res, err := s.client.get(ctx, k.URI(), &t)
 	return t, res, err
 }
 
-// Create requests creation of a DirectionalPool by DirectionalPoolKey given a directional-pool
-func (s *IPDirectionalPoolsService) Create(k IPDirectionalPoolKey, val interface{}) (*http.Response, error) {
-	return s.client.post(k.URI(), val, nil)
+// Create requests creation of a directional group; its Name defaults to that of k
+func (s *IPDirectionalPoolsService) Create(k IPDirectionalPoolKey, val AccountLevelIPDirectionalGroupDTO) (*http.Response, error) {
+	return s.CreateContext(context.Background(), k, val)
 }
 
-// Update requests update of a DirectionalPool by DirectionalPoolKey given a directional-pool
-func (s *IPDirectionalPoolsService) Update(k IPDirectionalPoolKey, val interface{}) (*http.Response, error) {
-	return s.client.put(k.URI(), val, nil)
+// CreateContext is Create with a context.Context
+func (s *IPDirectionalPoolsService) CreateContext(ctx context.Context, k IPDirectionalPoolKey, val AccountLevelIPDirectionalGroupDTO) (*http.Response, error) {
+	if val.Name == "" {
+		val.Name = k.Name
+	}
+	if err := s.validate(val); err != nil {
+		return nil, err
+	}
+	return s.client.post(ctx, k.URI(), val, nil)
+}
+
+// Update requests update of a directional group; its Name defaults to that of k
+func (s *IPDirectionalPoolsService) Update(k IPDirectionalPoolKey, val AccountLevelIPDirectionalGroupDTO) (*http.Response, error) {
+	return s.UpdateContext(context.Background(), k, val)
+}
+
+// UpdateContext is Update with a context.Context
+func (s *IPDirectionalPoolsService) UpdateContext(ctx context.Context, k IPDirectionalPoolKey, val AccountLevelIPDirectionalGroupDTO) (*http.Response, error) {
+	if val.Name == "" {
+		val.Name = k.Name
+	}
+	if err := s.validate(val); err != nil {
+		return nil, err
+	}
+	return s.client.put(ctx, k.URI(), val, nil)
+}
+
+// validate checks the ranges of an IP group if the client validates them
+func (s *IPDirectionalPoolsService) validate(g AccountLevelIPDirectionalGroupDTO) error {
+	if !s.client.ValidateIPGroups {
+		return nil
+	}
+	return g.Validate()
 }
 
 // Delete deletes an  directional-pool
 func (s *IPDirectionalPoolsService) Delete(k IPDirectionalPoolKey) (*http.Response, error) {
-	return s.client.delete(k.URI(), nil)
+	return s.DeleteContext(context.Background(), k)
+}
+
+// DeleteContext is Delete with a context.Context
+func (s *IPDirectionalPoolsService) DeleteContext(ctx context.Context, k IPDirectionalPoolKey) (*http.Response, error) {
+	return s.client.delete(ctx, k.URI(), nil)
 }
@@ -0,0 +1,180 @@
+package udnssdk
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strings"
+)
+
+// DirPoolMatch tells what decided the answer of a directional pool
+type DirPoolMatch string
+
+// Here lie the ways a client can be matched by a directional pool
+const (
+	// DirPoolMatchIP is a match of the client's address in the ipInfo of a record
+	DirPoolMatchIP DirPoolMatch = "IP"
+	// DirPoolMatchGeo is a match of the client's location in the geoInfo of a record
+	DirPoolMatchGeo DirPoolMatch = "GEO"
+	// DirPoolMatchAllNonConfigured is the record of the clients no group matched
+	DirPoolMatchAllNonConfigured DirPoolMatch = "ALL_NON_CONFIGURED"
+	// DirPoolMatchNone is a client no group matched, in a pool without an allNonConfigured record
+	DirPoolMatchNone DirPoolMatch = "NONE"
+)
+
+// DirPoolClient is a client resolving a directional pool
+type DirPoolClient struct {
+	// IP is the address the pool sees, usually that of the client's resolver; the zero Addr matches no ipInfo
+	IP netip.Addr
+	// Geo lists the geo codes of the client's location, most specific first, as GeoCodePath returns them.
+	// The group holding the first of them that any group holds is the geo match.
+	Geo []string
+}
+
+// DirPoolAnswer is the answer a directional pool gives a client
+type DirPoolAnswer struct {
+	// RData is the record served, or "" if the client gets no answer
+	RData string
+	// Index is the index of RData in the RRSet, or -1 if the client gets no answer
+	Index int
+	// Match is what decided the answer
+	Match DirPoolMatch
+	// Group is the name of the matched geo or ip group
+	Group string
+	// NoResponse is set when the match is in the noResponse of the profile, which answers nothing
+	NoResponse bool
+}
+
+// DirPoolSimulator answers as a directional pool would, offline, so that routing changes can be tested before they are applied.
+//
+//	s, err := NewDirPoolSimulator(rrset)
+//	a, err := s.Answer(DirPoolClient{IP: netip.MustParseAddr("192.0.2.1"), Geo: []string{"FR", "EUR"}})
+type DirPoolSimulator struct {
+	Profile DirPoolProfile
+	RData   []string
+	// AccountGeoGroups are the codes of account-level geo groups by name, for the geoInfo listing none
+	AccountGeoGroups map[string][]string
+	// AccountIPGroups are the ranges of account-level ip groups by name, for the ipInfo listing none
+	AccountIPGroups map[string][]IPAddrDTO
+}
+
+// NewDirPoolSimulator returns a DirPoolSimulator for a directional pool RRSet
+func NewDirPoolSimulator(rr RRSet) (*DirPoolSimulator, error) {
+	var p DirPoolProfile
+	switch v := rr.Profile.(type) {
+	case DirPoolProfile:
+		p = v
+	case RawProfile:
+		var err error
+		if p, err = v.DirPoolProfile(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%s %s is not a directional pool", rr.RRType, rr.OwnerName)
+	}
+	if len(p.RDataInfo) != len(rr.RData) {
+		return nil, fmt.Errorf("profile has %d rdataInfo for %d rdata", len(p.RDataInfo), len(rr.RData))
+	}
+	return &DirPoolSimulator{Profile: p, RData: rr.RData}, nil
+}
+
+// dirPoolMatch is a candidate answer: the record at index, or the noResponse if index is -1
+type dirPoolMatch struct {
+	index int
+	group string
+	// rank orders candidates of the same kind, lowest first
+	rank *big.Int
+}
+
+// Answer returns the answer the pool gives c. A client matched by both an ip and a geo group gets
+// the one the profile's conflictResolve prefers, GEO unless it is IP; the narrowest range and the
+// most specific geo code win among groups of the same kind.
+func (s *DirPoolSimulator) Answer(c DirPoolClient) (DirPoolAnswer, error) {
+	infos := append([]DPRDataInfo{}, s.Profile.RDataInfo...)
+	if s.Profile.NoResponse != (DPRDataInfo{}) {
+		infos = append(infos, s.Profile.NoResponse)
+	}
+	index := func(i int) int {
+		if i >= len(s.Profile.RDataInfo) {
+			return -1
+		}
+		return i
+	}
+
+	var ip, geo, all *dirPoolMatch
+	for i, info := range infos {
+		if info.IPInfo != nil && c.IP.IsValid() {
+			m, err := s.matchIP(*info.IPInfo, c.IP)
+			if err != nil {
+				return DirPoolAnswer{}, fmt.Errorf("rdataInfo %d: %w", i, err)
+			}
+			if m != nil && (ip == nil || m.Cmp(ip.rank) < 0) {
+				ip = &dirPoolMatch{index: index(i), group: info.IPInfo.Name, rank: m}
+			}
+		}
+		if info.GeoInfo != nil {
+			if m := s.matchGeo(*info.GeoInfo, c.Geo); m >= 0 && (geo == nil || int64(m) < geo.rank.Int64()) {
+				geo = &dirPoolMatch{index: index(i), group: info.GeoInfo.Name, rank: big.NewInt(int64(m))}
+			}
+		}
+		if info.AllNonConfigured && all == nil {
+			all = &dirPoolMatch{index: index(i)}
+		}
+	}
+
+	switch {
+	case ip != nil && (geo == nil || strings.EqualFold(s.Profile.ConflictResolve, "IP")):
+		return s.answer(ip, DirPoolMatchIP), nil
+	case geo != nil:
+		return s.answer(geo, DirPoolMatchGeo), nil
+	case all != nil:
+		return s.answer(all, DirPoolMatchAllNonConfigured), nil
+	}
+	return DirPoolAnswer{Index: -1, Match: DirPoolMatchNone}, nil
+}
+
+func (s *DirPoolSimulator) answer(m *dirPoolMatch, match DirPoolMatch) DirPoolAnswer {
+	a := DirPoolAnswer{Index: m.index, Match: match, Group: m.group, NoResponse: m.index < 0}
+	if m.index >= 0 {
+		a.RData = s.RData[m.index]
+	}
+	return a
+}
+
+// matchIP returns the size of the narrowest range of g holding addr, or nil if none does
+func (s *DirPoolSimulator) matchIP(g IPInfo, addr netip.Addr) (*big.Int, error) {
+	ips := g.Ips
+	if len(ips) == 0 {
+		ips = s.AccountIPGroups[g.Name]
+	}
+	var best *big.Int
+	for _, dto := range ips {
+		r, err := dto.Range()
+		if err != nil {
+			return nil, err
+		}
+		if !r.Contains(addr) {
+			continue
+		}
+		if size := r.size(); best == nil || size.Cmp(best) < 0 {
+			best = size
+		}
+	}
+	return best, nil
+}
+
+// matchGeo returns the index in codes of the first code g holds, or -1 if it holds none
+func (s *DirPoolSimulator) matchGeo(g GeoInfo, codes []string) int {
+	held := g.Codes
+	if len(held) == 0 {
+		held = s.AccountGeoGroups[g.Name]
+	}
+	for i, c := range codes {
+		for _, h := range held {
+			if strings.EqualFold(c, h) {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,105 @@
+package udnssdk
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func Test_DirPoolSimulator_Answer(t *testing.T) {
+	rr := RRSet{
+		OwnerName: "www",
+		RRType:    "A",
+		RData:     []string{"192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4"},
+		Profile: DirPoolProfile{
+			RDataInfo: []DPRDataInfo{
+				{GeoInfo: &GeoInfo{Name: "europe", Codes: []string{"EUR"}}},
+				{GeoInfo: &GeoInfo{Name: "france", Codes: []string{"FR"}}, IPInfo: &IPInfo{Name: "office", Ips: []IPAddrDTO{{CIDR: "198.51.100.0/24"}}}},
+				{IPInfo: &IPInfo{Name: "lab", Ips: []IPAddrDTO{{Start: "198.51.100.10", End: "198.51.100.20"}, {Address: "2001:db8::1"}}}},
+				{AllNonConfigured: true},
+			},
+			NoResponse: DPRDataInfo{GeoInfo: &GeoInfo{Name: "blocked", Codes: []string{"A1"}}},
+		},
+	}
+	s, err := NewDirPoolSimulator(rr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		client DirPoolClient
+		want   DirPoolAnswer
+	}{
+		{"continent", DirPoolClient{Geo: []string{"DE", "EUR"}}, DirPoolAnswer{RData: "192.0.2.1", Index: 0, Match: DirPoolMatchGeo, Group: "europe"}},
+		{"most specific code", DirPoolClient{Geo: []string{"fr", "EUR"}}, DirPoolAnswer{RData: "192.0.2.2", Index: 1, Match: DirPoolMatchGeo, Group: "france"}},
+		{"ip", DirPoolClient{IP: netip.MustParseAddr("198.51.100.99")}, DirPoolAnswer{RData: "192.0.2.2", Index: 1, Match: DirPoolMatchIP, Group: "office"}},
+		{"narrowest range", DirPoolClient{IP: netip.MustParseAddr("198.51.100.15")}, DirPoolAnswer{RData: "192.0.2.3", Index: 2, Match: DirPoolMatchIP, Group: "lab"}},
+		{"ipv6 address", DirPoolClient{IP: netip.MustParseAddr("2001:db8::1")}, DirPoolAnswer{RData: "192.0.2.3", Index: 2, Match: DirPoolMatchIP, Group: "lab"}},
+		{"geo wins conflicts", DirPoolClient{IP: netip.MustParseAddr("198.51.100.15"), Geo: []string{"DE", "EUR"}}, DirPoolAnswer{RData: "192.0.2.1", Index: 0, Match: DirPoolMatchGeo, Group: "europe"}},
+		{"all non-configured", DirPoolClient{IP: netip.MustParseAddr("203.0.113.1"), Geo: []string{"US", "NAM"}}, DirPoolAnswer{RData: "192.0.2.4", Index: 3, Match: DirPoolMatchAllNonConfigured}},
+		{"no response", DirPoolClient{Geo: []string{"A1"}}, DirPoolAnswer{Index: -1, Match: DirPoolMatchGeo, Group: "blocked", NoResponse: true}},
+	}
+	for _, c := range cases {
+		got, err := s.Answer(c.client)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if got != c.want {
+			t.Errorf("%s: Answer(%+v) = %+v, want: %+v", c.name, c.client, got, c.want)
+		}
+	}
+
+	s.Profile.ConflictResolve = "IP"
+	got, _ := s.Answer(DirPoolClient{IP: netip.MustParseAddr("198.51.100.15"), Geo: []string{"DE", "EUR"}})
+	if got.Index != 2 || got.Match != DirPoolMatchIP {
+		t.Errorf("conflictResolve IP: %+v", got)
+	}
+
+	s.Profile.RDataInfo = s.Profile.RDataInfo[:3]
+	s.RData = s.RData[:3]
+	got, _ = s.Answer(DirPoolClient{Geo: []string{"US"}})
+	if want := (DirPoolAnswer{Index: -1, Match: DirPoolMatchNone}); got != want {
+		t.Errorf("no match: %+v, want: %+v", got, want)
+	}
+}
+
+func Test_DirPoolSimulator_AccountLevelGroups(t *testing.T) {
+	rr := RRSet{
+		RData: []string{"192.0.2.1", "192.0.2.2"},
+		Profile: RawProfile{
+			"@context": string(DirPoolSchema),
+			"rdataInfo": []interface{}{
+				map[string]interface{}{"geoInfo": map[string]interface{}{"name": "shared-geo", "isAccountLevel": true}},
+				map[string]interface{}{"ipInfo": map[string]interface{}{"name": "shared-ip", "isAccountLevel": true}},
+			},
+		},
+	}
+	s, err := NewDirPoolSimulator(rr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AccountGeoGroups = map[string][]string{"shared-geo": {"JP"}}
+	s.AccountIPGroups = map[string][]IPAddrDTO{"shared-ip": {{CIDR: "10.0.0.0/8"}}}
+
+	if got, _ := s.Answer(DirPoolClient{Geo: []string{"JP", "ASI"}}); got.Index != 0 {
+		t.Errorf("geo: %+v", got)
+	}
+	if got, _ := s.Answer(DirPoolClient{IP: netip.MustParseAddr("10.1.2.3")}); got.Index != 1 {
+		t.Errorf("ip: %+v", got)
+	}
+}
+
+func Test_NewDirPoolSimulator_Errors(t *testing.T) {
+	if _, err := NewDirPoolSimulator(RRSet{RData: []string{"192.0.2.1"}, Profile: RDPoolProfile{}}); err == nil {
+		t.Error("NewDirPoolSimulator: no error for an RD pool")
+	}
+	if _, err := NewDirPoolSimulator(RRSet{RData: []string{"192.0.2.1"}, Profile: DirPoolProfile{}}); err == nil {
+		t.Error("NewDirPoolSimulator: no error for missing rdataInfo")
+	}
+	s, _ := NewDirPoolSimulator(RRSet{RData: []string{"192.0.2.1"}, Profile: DirPoolProfile{RDataInfo: []DPRDataInfo{
+		{IPInfo: &IPInfo{Name: "bad", Ips: []IPAddrDTO{{Start: "192.0.2.9", End: "192.0.2.1"}}}},
+	}}})
+	if _, err := s.Answer(DirPoolClient{IP: netip.MustParseAddr("192.0.2.5")}); err == nil {
+		t.Error("Answer: no error for an inverted range")
+	}
+}
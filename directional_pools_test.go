@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -449,3 +450,74 @@ func Test_IPDirectionalPoolsService_Update(t *testing.T) {
 func Test_IPDirectionalPoolsService_Delete(t *testing.T) {
 	t.SkipNow()
 }
+
+func Test_DirectionalPoolsService_Select(t *testing.T) {
+	geos := []AccountLevelGeoDirectionalGroupDTO{
+		{Name: "unicorn", Description: "unicorn: a service of rainbows", Codes: []string{"US", "CA"}},
+	}
+	ips := []AccountLevelIPDirectionalGroupDTO{
+		{Name: "pegasus", Description: "pegasus: a service of clouds", IPs: []IPAddrDTO{{CIDR: "192.0.2.0/24"}}},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp interface{}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/udnssdk/dirgroups/geo"):
+			resp = AccountLevelGeoDirectionalGroupListDTO{
+				GeoGroups:  geos,
+				Resultinfo: ResultInfo{TotalCount: len(geos), ReturnedCount: len(geos)},
+			}
+		case strings.HasSuffix(r.URL.Path, "/accounts/udnssdk/dirgroups/ip"):
+			resp = AccountLevelIPDirectionalGroupListDTO{
+				IPGroups:   ips,
+				Resultinfo: ResultInfo{TotalCount: len(ips), ReturnedCount: len(ips)},
+			}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		mess, _ := json.Marshal(resp)
+		fmt.Fprintln(w, string(mess))
+	}))
+	defer ts.Close()
+	c, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+
+	k := DirectionalPoolKey{Account: AccountKey("udnssdk")}
+	ps, err := c.DirectionalPools.Select(k, Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []DirectionalPool{geos[0].DirectionalPool(), ips[0].DirectionalPool()}
+	if !reflect.DeepEqual(ps, want) {
+		t.Errorf("Select: %+v, want: %+v", ps, want)
+	}
+	if want[0].Type != GeoDirectionalPoolType || want[1].Type != IPDirectionalPoolType {
+		t.Errorf("Types: %q, %q", want[0].Type, want[1].Type)
+	}
+
+	ps, err = c.DirectionalPools.Select(k, Query{Sort: "NAME", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ps, []DirectionalPool{want[1], want[0]}) {
+		t.Errorf("Select by NAME: %+v, want: %+v", ps, []DirectionalPool{want[1], want[0]})
+	}
+	ps, err = c.DirectionalPools.Select(k, Query{Sort: "NAME", Reverse: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ps, want) {
+		t.Errorf("Select by NAME reversed: %+v, want: %+v", ps, want)
+	}
+	if _, err := c.DirectionalPools.Select(k, Query{Sort: "OWNER"}); err == nil {
+		t.Error("Select by OWNER: no error")
+	}
+
+	k.Type = IPDirectionalPoolType
+	ps, err = c.DirectionalPools.Select(k, Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ps, want[1:]) {
+		t.Errorf("Select ip: %+v, want: %+v", ps, want[1:])
+	}
+}
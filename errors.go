@@ -0,0 +1,151 @@
+package udnssdk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// Sentinel errors classifying API failures. Match them with errors.Is,
+// or the IsNotFound, IsAlreadyExists, ... predicates.
+var (
+	// ErrNotFound matches errors for resources that do not exist
+	ErrNotFound = errors.New("udnssdk: not found")
+	// ErrAlreadyExists matches errors for resources that already exist
+	ErrAlreadyExists = errors.New("udnssdk: already exists")
+	// ErrUnauthorized matches authentication and authorization failures, including token requests
+	ErrUnauthorized = errors.New("udnssdk: unauthorized")
+	// ErrRateLimited matches requests rejected for exceeding the API rate limit
+	ErrRateLimited = errors.New("udnssdk: rate limited")
+	// ErrValidation matches requests rejected as malformed or invalid
+	ErrValidation = errors.New("udnssdk: validation failed")
+)
+
+// UltraDNS error codes with a known classification
+const (
+	ErrorCodeZoneNotFound       = 1801
+	ErrorCodeZoneAlreadyExists  = 1802
+	ErrorCodeRRSetAlreadyExists = 2111
+	ErrorCodeRRSetNotFound      = 56001
+	ErrorCodeInvalidGrant       = 60001
+	ErrorCodeDataNotFound       = 70002
+)
+
+// errorCodeClasses maps UltraDNS error codes to the sentinel they match,
+// taking precedence over the HTTP status of the response
+var errorCodeClasses = map[int]error{
+	ErrorCodeZoneNotFound:       ErrNotFound,
+	ErrorCodeZoneAlreadyExists:  ErrAlreadyExists,
+	ErrorCodeRRSetAlreadyExists: ErrAlreadyExists,
+	ErrorCodeRRSetNotFound:      ErrNotFound,
+	ErrorCodeInvalidGrant:       ErrUnauthorized,
+	ErrorCodeDataNotFound:       ErrNotFound,
+}
+
+// classifyStatus returns the sentinel matching an HTTP status, or nil
+func classifyStatus(status int) error {
+	switch status {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrAlreadyExists
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrValidation
+	}
+	return nil
+}
+
+// classify returns the sentinel matching the ErrorResponse, or nil
+func (r ErrorResponse) classify() error {
+	if c, ok := errorCodeClasses[r.ErrorCode]; ok {
+		return c
+	}
+	if r.ErrorStr == "invalid_grant" || r.ErrorStr == "invalid_token" {
+		return ErrUnauthorized
+	}
+	return classifyStatus(statusCode(r.Response))
+}
+
+// Is reports whether the ErrorResponse matches target, one of the sentinel errors
+func (r ErrorResponse) Is(target error) bool {
+	c := r.classify()
+	return c != nil && c == target
+}
+
+// Is reports whether the list matches target, one of the sentinel errors.
+// The list's own status is consulted when it holds no ErrorResponses;
+// otherwise errors.Is checks each of them through Unwrap.
+func (r ErrorResponseList) Is(target error) bool {
+	if len(r.Responses) != 0 {
+		return false
+	}
+	c := classifyStatus(statusCode(r.Response))
+	return c != nil && c == target
+}
+
+// Is reports whether the StatusError matches target, one of the sentinel errors
+func (r *StatusError) Is(target error) bool {
+	c := classifyStatus(statusCode(r.Response))
+	return c != nil && c == target
+}
+
+// IsNotFound reports whether err is an API error for a resource that does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsAlreadyExists reports whether err is an API error for a resource that already exists
+func IsAlreadyExists(err error) bool {
+	return errors.Is(err, ErrAlreadyExists)
+}
+
+// IsUnauthorized reports whether err is an authentication or authorization failure
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsRateLimited reports whether err is an API error for an exceeded rate limit
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsValidation reports whether err is an API error for a malformed or invalid request
+func IsValidation(err error) bool {
+	return errors.Is(err, ErrValidation)
+}
+
+// tokenError wraps a failed token request so it matches ErrUnauthorized when the credentials were rejected.
+// Other token failures, such as network errors, are returned unchanged.
+func tokenError(err error) error {
+	var re *oauth2.RetrieveError
+	if !errors.As(err, &re) || re.Response == nil {
+		return err
+	}
+	switch re.Response.StatusCode {
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %w", ErrUnauthorized, err)
+	}
+	return err
+}
+
+// requestString describes the request of r for error messages
+func requestString(r *http.Response) string {
+	if r == nil || r.Request == nil {
+		return "<unknown request>"
+	}
+	return fmt.Sprintf("%v %v", r.Request.Method, r.Request.URL)
+}
+
+// statusCode returns the status of r, or 0 if there is none
+func statusCode(r *http.Response) int {
+	if r == nil {
+		return 0
+	}
+	return r.StatusCode
+}
@@ -0,0 +1,132 @@
+package udnssdk
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func testErrorHTTPResponse(status int, body string) *http.Response {
+	u, _ := url.Parse("https://restapi.ultradns.com/v1/zones/example.com./rrsets/A/foo")
+	return &http.Response{
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Request:    &http.Request{Method: "GET", URL: u},
+	}
+}
+
+func Test_CheckResponse_Classification(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{404, `{"errorCode":70002,"errorMessage":"Data not found."}`, ErrNotFound},
+		{400, `[{"errorCode":56001,"errorMessage":"Cannot find resource record data for the input zone, record type and owner combination."}]`, ErrNotFound},
+		{400, `{"errorCode":1801,"errorMessage":"Zone does not exist in the system."}`, ErrNotFound},
+		{400, `[{"errorCode":2111,"errorMessage":"Resource Record of type 1 with these attributes already exists in the system."}]`, ErrAlreadyExists},
+		{400, `{"errorCode":1802,"errorMessage":"Zone already exists in the system."}`, ErrAlreadyExists},
+		{401, `{"errorCode":60001,"errorMessage":"invalid_grant:Invalid username & password combination.","error":"invalid_grant"}`, ErrUnauthorized},
+		{403, `{"errorCode":8001,"errorMessage":"Permission denied."}`, ErrUnauthorized},
+		{429, `{"errorCode":0,"errorMessage":"Too many requests."}`, ErrRateLimited},
+		{429, ``, ErrRateLimited},
+		{400, `[{"errorCode":49001,"errorMessage":"Invalid json."}]`, ErrValidation},
+		{404, `[]`, ErrNotFound},
+	}
+
+	sentinels := []error{ErrNotFound, ErrAlreadyExists, ErrUnauthorized, ErrRateLimited, ErrValidation}
+	for i, c := range cases {
+		err := CheckResponse(testErrorHTTPResponse(c.status, c.body))
+		for _, s := range sentinels {
+			if got := errors.Is(err, s); got != (s == c.want) {
+				t.Errorf("case %d: errors.Is(%v, %v) = %v", i, err, s, got)
+			}
+		}
+	}
+}
+
+func Test_Is_Predicates(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", CheckResponse(testErrorHTTPResponse(404, `{"errorCode":70002,"errorMessage":"Data not found."}`)))
+
+	if !IsNotFound(err) {
+		t.Errorf("IsNotFound(%v) = false", err)
+	}
+	if IsAlreadyExists(err) || IsUnauthorized(err) || IsRateLimited(err) || IsValidation(err) {
+		t.Errorf("%v matched more than one predicate", err)
+	}
+	if IsNotFound(nil) {
+		t.Errorf("IsNotFound(nil) = true")
+	}
+}
+
+func Test_ErrorResponseList_As(t *testing.T) {
+	err := CheckResponse(testErrorHTTPResponse(400, `[{"errorCode":56001,"errorMessage":"Cannot find resource record data"},{"errorCode":2,"errorMessage":"Other"}]`))
+
+	var list *ErrorResponseList
+	if !errors.As(err, &list) {
+		t.Fatalf("errors.As(%#v, *ErrorResponseList) = false", err)
+	}
+	if len(list.Responses) != 2 {
+		t.Errorf("len(Responses): %d, want: %d", len(list.Responses), 2)
+	}
+
+	var er ErrorResponse
+	if !errors.As(err, &er) {
+		t.Fatalf("errors.As(%#v, ErrorResponse) = false", err)
+	}
+	if er.ErrorCode != ErrorCodeRRSetNotFound || er.Response == nil {
+		t.Errorf("ErrorResponse: %+v, want ErrorCode %d with Response", er, ErrorCodeRRSetNotFound)
+	}
+}
+
+func Test_ErrorResponseList_Error_Empty(t *testing.T) {
+	err := ErrorResponseList{}
+	want := "<unknown request>: 0"
+
+	if err.Error() != want {
+		t.Errorf("Error(): %q, want: %q", err.Error(), want)
+	}
+
+	err = ErrorResponseList{Response: testErrorHTTPResponse(500, "")}
+	want = "GET https://restapi.ultradns.com/v1/zones/example.com./rrsets/A/foo: 500"
+	if err.Error() != want {
+		t.Errorf("Error(): %q, want: %q", err.Error(), want)
+	}
+}
+
+func Test_ErrorResponse_Error_NoResponse(t *testing.T) {
+	err := ErrorResponse{ErrorCode: 70002, ErrorMessage: "Data not found."}
+	want := "<unknown request>: 0 70002 Data not found."
+
+	if err.Error() != want {
+		t.Errorf("Error(): %q, want: %q", err.Error(), want)
+	}
+}
+
+func Test_TokenRejected_IsUnauthorized(t *testing.T) {
+	hits := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintln(w, `{"errorCode":60001,"errorMessage":"invalid_grant:Invalid username & password combination.","error":"invalid_grant"}`)
+	}))
+	defer ts.Close()
+	c, _ := NewClient("samdoe", "wrong", ts.URL)
+
+	_, _, err := c.Accounts.Select()
+
+	if !IsUnauthorized(err) {
+		t.Errorf("IsUnauthorized(%v) = false", err)
+	}
+	// oauth2 tries both client authentication styles once; the RetryPolicy must not add more
+	if hits > 2 {
+		t.Errorf("token requests: %d, want at most: %d", hits, 2)
+	}
+}
@@ -1,8 +1,8 @@
 package udnssdk
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"net/http"
 	"time"
 )
@@ -54,46 +54,58 @@ func (p EventKey) URI() string {
 
 // Select requests all events, using pagination and error handling
 func (s *EventsService) Select(r RRSetKey, query string) ([]EventInfoDTO, error) {
-	// TODO: Sane Configuration for timeouts / retries
-	maxerrs := 5
-	waittime := 5 * time.Second
+	return s.SelectContext(context.Background(), r, query)
+}
 
-	// init accumulators
-	pis := []EventInfoDTO{}
-	offset := 0
-	errcnt := 0
-
-	for {
-		reqEvents, ri, res, err := s.SelectWithOffset(r, query, offset)
-		if err != nil {
-			if res != nil && res.StatusCode >= 500 {
-				errcnt = errcnt + 1
-				if errcnt < maxerrs {
-					time.Sleep(waittime)
-					continue
-				}
-			}
-			return pis, err
-		}
-
-		log.Printf("ResultInfo: %+v\n", ri)
-		for _, pi := range reqEvents {
-			pis = append(pis, pi)
-		}
-		if ri.ReturnedCount+ri.Offset >= ri.TotalCount {
-			return pis, nil
-		}
-		offset = ri.ReturnedCount + ri.Offset
-		continue
-	}
+// SelectContext is Select with a context.Context
+func (s *EventsService) SelectContext(ctx context.Context, r RRSetKey, query string) ([]EventInfoDTO, error) {
+	return NewPager(s.client, func(ctx context.Context, _ QueryInfo, offset int) ([]EventInfoDTO, ResultInfo, *http.Response, error) {
+		return s.SelectWithOffsetContext(ctx, r, query, offset)
+	}).Collect(ctx)
 }
 
 // SelectWithOffset requests list of events by RRSetKey, query and offset, also returning list metadata, the actual response, or an error
 func (s *EventsService) SelectWithOffset(r RRSetKey, query string, offset int) ([]EventInfoDTO, ResultInfo, *http.Response, error) {
+	return s.SelectWithOffsetContext(context.Background(), r, query, offset)
+}
+
+// SelectWithOffsetContext is SelectWithOffset with a context.Context
+func (s *EventsService) SelectWithOffsetContext(ctx context.Context, r RRSetKey, query string, offset int) ([]EventInfoDTO, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, r.EventsQueryURI(query, offset))
+}
+
+// SelectQuery requests all events by RRSetKey matching q, using pagination and error handling
+func (s *EventsService) SelectQuery(r RRSetKey, q Query) ([]EventInfoDTO, error) {
+	return s.SelectQueryContext(context.Background(), r, q)
+}
+
+// SelectQueryContext is SelectQuery with a context.Context
+func (s *EventsService) SelectQueryContext(ctx context.Context, r RRSetKey, q Query) ([]EventInfoDTO, error) {
+	return s.Pager(r, q).Collect(ctx)
+}
+
+// SelectQueryWithOffset requests list of events by RRSetKey matching q, starting at offset, also returning list metadata, the actual response, or an error
+func (s *EventsService) SelectQueryWithOffset(r RRSetKey, q Query, offset int) ([]EventInfoDTO, ResultInfo, *http.Response, error) {
+	return s.SelectQueryWithOffsetContext(context.Background(), r, q, offset)
+}
+
+// SelectQueryWithOffsetContext is SelectQueryWithOffset with a context.Context
+func (s *EventsService) SelectQueryWithOffsetContext(ctx context.Context, r RRSetKey, q Query, offset int) ([]EventInfoDTO, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, q.URI(r.EventsURI(), offset))
+}
+
+// Pager returns a Pager over the events of r matching q
+func (s *EventsService) Pager(r RRSetKey, q Query) *Pager[EventInfoDTO] {
+	return newQueryPager(s.client, q, func(ctx context.Context, q Query, offset int) ([]EventInfoDTO, ResultInfo, *http.Response, error) {
+		return s.SelectQueryWithOffsetContext(ctx, r, q, offset)
+	})
+}
+
+// selectURI requests a page of events from uri
+func (s *EventsService) selectURI(ctx context.Context, uri string) ([]EventInfoDTO, ResultInfo, *http.Response, error) {
 	var tld EventInfoListDTO
 
-	uri := r.EventsQueryURI(query, offset)
-	res, err := s.client.get(uri, &tld)
+	res, err := s.client.get(ctx, uri, &tld)
 
 	pis := []EventInfoDTO{}
 	for _, pi := range tld.Events {
@@ -104,22 +116,42 @@ func (s *EventsService) SelectWithOffset(r RRSetKey, query string, offset int) (
 
 // Find requests an event by name, type, zone & guid, also returning the actual response, or an error
 func (s *EventsService) Find(e EventKey) (EventInfoDTO, *http.Response, error) {
+	return s.FindContext(context.Background(), e)
+}
+
+// FindContext is Find with a context.Context
+func (s *EventsService) FindContext(ctx context.Context, e EventKey) (EventInfoDTO, *http.Response, error) {
 	var t EventInfoDTO
-	res, err := s.client.get(e.URI(), &t)
+	res, err := s.client.get(ctx, e.URI(), &t)
 	return t, res, err
 }
 
 // Create requests creation of an event by RRSetKey, with provided event-info, returning actual response or an error
 func (s *EventsService) Create(r RRSetKey, ev EventInfoDTO) (*http.Response, error) {
-	return s.client.post(r.EventsURI(), ev, nil)
+	return s.CreateContext(context.Background(), r, ev)
+}
+
+// CreateContext is Create with a context.Context
+func (s *EventsService) CreateContext(ctx context.Context, r RRSetKey, ev EventInfoDTO) (*http.Response, error) {
+	return s.client.post(ctx, r.EventsURI(), ev, nil)
 }
 
 // Update requests update of an event by EventKey, withprovided event-info, returning the actual response or an error
 func (s *EventsService) Update(e EventKey, ev EventInfoDTO) (*http.Response, error) {
-	return s.client.put(e.URI(), ev, nil)
+	return s.UpdateContext(context.Background(), e, ev)
+}
+
+// UpdateContext is Update with a context.Context
+func (s *EventsService) UpdateContext(ctx context.Context, e EventKey, ev EventInfoDTO) (*http.Response, error) {
+	return s.client.put(ctx, e.URI(), ev, nil)
 }
 
 // Delete requests deletion of an event by EventKey, returning the actual response or an error
 func (s *EventsService) Delete(e EventKey) (*http.Response, error) {
-	return s.client.delete(e.URI(), nil)
+	return s.DeleteContext(context.Background(), e)
+}
+
+// DeleteContext is Delete with a context.Context
+func (s *EventsService) DeleteContext(ctx context.Context, e EventKey) (*http.Response, error) {
+	return s.client.delete(ctx, e.URI(), nil)
 }
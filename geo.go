@@ -0,0 +1,264 @@
+package udnssdk
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// geoCodesTSV is the catalog of geo codes, one "code, name, parent" line each, regions before the territories they hold
+//
+//go:embed geo_codes.tsv
+var geoCodesTSV string
+
+// GeoCode is a territory of the UltraDNS geo code tree geo directional groups are made of:
+// a continent, a special group such as A1 (anonymous proxies), a country by its ISO 3166-1 code,
+// or a US state or Canadian province by its ISO 3166-2 code, e.g. US-CA
+type GeoCode struct {
+	Code string
+	Name string
+	// Parent is the code of the region holding the territory, "" for continents and special groups
+	Parent string
+}
+
+// geoCatalog indexes the geo codes
+type geoCatalog struct {
+	codes    []GeoCode
+	byCode   map[string]int
+	byName   map[string]int
+	children map[string][]string
+}
+
+var geoCodes = parseGeoCodes(geoCodesTSV)
+
+func parseGeoCodes(tsv string) *geoCatalog {
+	c := &geoCatalog{byCode: map[string]int{}, byName: map[string]int{}, children: map[string][]string{}}
+	for _, line := range strings.Split(tsv, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f := strings.Split(line, "\t")
+		g := GeoCode{Code: f[0], Name: f[1], Parent: f[2]}
+		c.byCode[g.Code] = len(c.codes)
+		c.byName[geoName(g.Name)] = len(c.codes)
+		c.codes = append(c.codes, g)
+		if g.Parent != "" {
+			c.children[g.Parent] = append(c.children[g.Parent], g.Code)
+		}
+	}
+	return c
+}
+
+// geoName folds a territory name for lookups, so that "ANONYMOUS_PROXY" finds "Anonymous Proxy"
+func geoName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// GeoCodes returns the catalog of geo codes, each region before the territories it holds
+func GeoCodes() []GeoCode {
+	return append([]GeoCode{}, geoCodes.codes...)
+}
+
+// LookupGeoCode finds a geo code by its code, in any case, or by its name, ignoring case, spaces and punctuation
+func LookupGeoCode(s string) (GeoCode, bool) {
+	if i, ok := geoCodeIndex(s); ok {
+		return geoCodes.codes[i], true
+	}
+	if i, ok := geoCodes.byName[geoName(s)]; ok {
+		return geoCodes.codes[i], true
+	}
+	return GeoCode{}, false
+}
+
+// geoCodeIndex finds a code in the catalog. Codes are matched in any case and without surrounding spaces
+// everywhere in this package: by LookupGeoCode, ExpandGeoCodes, CollapseGeoCodes and ValidateGeoCodes.
+func geoCodeIndex(code string) (int, bool) {
+	i, ok := geoCodes.byCode[strings.ToUpper(strings.TrimSpace(code))]
+	return i, ok
+}
+
+// Children returns the territories g holds directly
+func (g GeoCode) Children() []GeoCode {
+	children := []GeoCode{}
+	for _, c := range geoCodes.children[g.Code] {
+		children = append(children, geoCodes.codes[geoCodes.byCode[c]])
+	}
+	return children
+}
+
+// GeoCodePath returns code followed by the codes of the regions holding it, most specific first,
+// e.g. US-CA, US, NAM; as the Geo of a DirPoolClient
+func GeoCodePath(code string) ([]string, error) {
+	g, ok := LookupGeoCode(code)
+	if !ok {
+		return nil, fmt.Errorf("unknown geo code %q", code)
+	}
+	path := []string{g.Code}
+	for g.Parent != "" {
+		g = geoCodes.codes[geoCodes.byCode[g.Parent]]
+		path = append(path, g.Code)
+	}
+	return path, nil
+}
+
+// ExpandGeoCodes replaces the regions in codes by the territories without subdivisions they hold, in catalog order
+func ExpandGeoCodes(codes []string) ([]string, error) {
+	leaves, err := geoLeaves(codes)
+	if err != nil {
+		return nil, err
+	}
+	expanded := []string{}
+	for _, g := range geoCodes.codes {
+		if leaves[g.Code] {
+			expanded = append(expanded, g.Code)
+		}
+	}
+	return expanded, nil
+}
+
+// CollapseGeoCodes replaces the territories in codes that make up a whole region by the region, in catalog order;
+// it is the shortest list of codes covering the same territories
+func CollapseGeoCodes(codes []string) ([]string, error) {
+	leaves, err := geoLeaves(codes)
+	if err != nil {
+		return nil, err
+	}
+	var covered func(code string) bool
+	covered = func(code string) bool {
+		children := geoCodes.children[code]
+		if len(children) == 0 {
+			return leaves[code]
+		}
+		for _, c := range children {
+			if !covered(c) {
+				return false
+			}
+		}
+		return true
+	}
+
+	collapsed := []string{}
+	var walk func(code string)
+	walk = func(code string) {
+		if covered(code) {
+			collapsed = append(collapsed, code)
+			return
+		}
+		for _, c := range geoCodes.children[code] {
+			walk(c)
+		}
+	}
+	for _, g := range geoCodes.codes {
+		if g.Parent == "" {
+			walk(g.Code)
+		}
+	}
+	return collapsed, nil
+}
+
+// geoLeaves returns the territories without subdivisions covered by codes
+func geoLeaves(codes []string) (map[string]bool, error) {
+	leaves := map[string]bool{}
+	var add func(code string)
+	add = func(code string) {
+		children := geoCodes.children[code]
+		if len(children) == 0 {
+			leaves[code] = true
+		}
+		for _, c := range children {
+			add(c)
+		}
+	}
+	for _, code := range codes {
+		g, ok := geoCodeIndex(code)
+		if !ok {
+			return nil, fmt.Errorf("unknown geo code %q", code)
+		}
+		add(geoCodes.codes[g].Code)
+	}
+	return leaves, nil
+}
+
+// GeoGroupValidationError lists the problems ValidateGeoCodes found with the codes of a geo group.
+// It matches ErrValidation, like the API's own validation errors.
+type GeoGroupValidationError struct {
+	Name     string
+	Problems []string
+}
+
+func (e *GeoGroupValidationError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("invalid geo codes: %s", strings.Join(e.Problems, "; "))
+	}
+	return fmt.Sprintf("invalid geo group %s: %s", e.Name, strings.Join(e.Problems, "; "))
+}
+
+// Is reports whether target is ErrValidation
+func (e *GeoGroupValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// ValidateGeoCodes checks that codes are known geo codes, in any case, each listed once and none held by another of them
+func ValidateGeoCodes(codes []string) error {
+	if problems := geoCodeProblems(codes); len(problems) != 0 {
+		return &GeoGroupValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// Validate checks the codes of the group
+func (g AccountLevelGeoDirectionalGroupDTO) Validate() error {
+	problems := []string{}
+	if len(g.Codes) == 0 {
+		problems = append(problems, "at least one code is required")
+	}
+	problems = append(problems, geoCodeProblems(g.Codes)...)
+	if len(problems) != 0 {
+		return &GeoGroupValidationError{Name: g.Name, Problems: problems}
+	}
+	return nil
+}
+
+// geoCodeProblems returns the problems ValidateGeoCodes reports
+func geoCodeProblems(codes []string) []string {
+	problems := []string{}
+	seen := map[string]bool{}
+	known := []string{}
+	for _, code := range codes {
+		if strings.ContainsAny(strings.TrimSpace(code), ", ") {
+			problems = append(problems, fmt.Sprintf("code %q is a list; give each code separately", code))
+			continue
+		}
+		i, ok := geoCodeIndex(code)
+		if !ok {
+			if l, found := LookupGeoCode(code); found {
+				problems = append(problems, fmt.Sprintf("unknown geo code %q; did you mean %q?", code, l.Code))
+			} else {
+				problems = append(problems, fmt.Sprintf("unknown geo code %q", code))
+			}
+			continue
+		}
+		c := geoCodes.codes[i].Code
+		if seen[c] {
+			problems = append(problems, fmt.Sprintf("code %q is listed more than once", c))
+			continue
+		}
+		seen[c] = true
+		known = append(known, c)
+	}
+	for _, code := range known {
+		path, _ := GeoCodePath(code)
+		for _, region := range path[1:] {
+			if seen[region] {
+				problems = append(problems, fmt.Sprintf("code %q is already held by %q", code, region))
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,167 @@
+package udnssdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_GeoCodes_Catalog(t *testing.T) {
+	codes := GeoCodes()
+	seen := map[string]bool{}
+	for _, g := range codes {
+		if seen[g.Code] {
+			t.Errorf("%s listed twice", g.Code)
+		}
+		if g.Parent != "" && !seen[g.Parent] {
+			t.Errorf("%s listed before its parent %s", g.Code, g.Parent)
+		}
+		seen[g.Code] = true
+	}
+	for _, c := range []string{"EUR", "NAM", "A1", "A2", "A3", "FR", "GB", "US", "US-CA", "CA-QC", "AQ"} {
+		if !seen[c] {
+			t.Errorf("%s missing from the catalog", c)
+		}
+	}
+}
+
+func Test_LookupGeoCode(t *testing.T) {
+	cases := map[string]string{
+		"fr":               "FR",
+		"France":           "FR",
+		"ANONYMOUS_PROXY":  "A1",
+		"united kingdom":   "GB",
+		"us-ca":            "US-CA",
+		"British Columbia": "CA-BC",
+	}
+	for in, want := range cases {
+		g, ok := LookupGeoCode(in)
+		if !ok || g.Code != want {
+			t.Errorf("LookupGeoCode(%q): %+v, %v, want: %s", in, g, ok, want)
+		}
+	}
+	if g, ok := LookupGeoCode("Atlantis"); ok {
+		t.Errorf("LookupGeoCode(Atlantis): %+v", g)
+	}
+
+	us, _ := LookupGeoCode("US")
+	if n := len(us.Children()); n != 51 {
+		t.Errorf("US children: %d, want: 51", n)
+	}
+}
+
+func Test_GeoCodePath(t *testing.T) {
+	path, err := GeoCodePath("us-tx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"US-TX", "US", "NAM"}; !reflect.DeepEqual(path, want) {
+		t.Errorf("GeoCodePath: %v, want: %v", path, want)
+	}
+	if _, err := GeoCodePath("ZZ"); err == nil {
+		t.Error("GeoCodePath(ZZ): no error")
+	}
+}
+
+func Test_ExpandCollapseGeoCodes(t *testing.T) {
+	expanded, err := ExpandGeoCodes([]string{"CA", "MX"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the 13 provinces and territories of Canada, and Mexico
+	have := map[string]bool{}
+	for _, c := range expanded {
+		have[c] = true
+	}
+	if len(expanded) != 14 || !have["MX"] || !have["CA-ON"] || have["CA"] {
+		t.Errorf("ExpandGeoCodes: %v", expanded)
+	}
+
+	collapsed, err := CollapseGeoCodes(append(expanded, "FR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"FR", "CA", "MX"}; !reflect.DeepEqual(collapsed, want) {
+		t.Errorf("CollapseGeoCodes: %v, want: %v", collapsed, want)
+	}
+
+	europe, _ := ExpandGeoCodes([]string{"EUR"})
+	collapsed, _ = CollapseGeoCodes(europe)
+	if want := []string{"EUR"}; !reflect.DeepEqual(collapsed, want) {
+		t.Errorf("CollapseGeoCodes(Europe): %v, want: %v", collapsed, want)
+	}
+
+	if _, err := ExpandGeoCodes([]string{"XX"}); err == nil {
+		t.Error("ExpandGeoCodes(XX): no error")
+	}
+}
+
+func Test_ValidateGeoCodes(t *testing.T) {
+	if err := ValidateGeoCodes([]string{"US", "gb", " A1 "}); err != nil {
+		t.Errorf("ValidateGeoCodes: %v", err)
+	}
+	cases := map[string][]string{
+		"is a list":                       {"US, UK"},
+		`code "US CA" is a list`:          {"US CA"},
+		`did you mean "FR"`:               {"France"},
+		`unknown geo code "UK"`:           {"UK"},
+		"listed more than once":           {"DE", "de"},
+		`"US-NY" is already held by "US"`: {"us", "US-NY"},
+	}
+	for want, codes := range cases {
+		err := ValidateGeoCodes(codes)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateGeoCodes(%q): %v, want error containing %q", codes, err, want)
+			continue
+		}
+		if !IsValidation(err) {
+			t.Errorf("IsValidation(%v): false", err)
+		}
+	}
+}
+
+func Test_DirPoolProfile_GeoValidation(t *testing.T) {
+	rr := RRSet{OwnerName: "www", RRType: "A", TTL: 300, RData: []string{"192.0.2.1", "192.0.2.2"}, Profile: DirPoolProfile{
+		RDataInfo: []DPRDataInfo{
+			{GeoInfo: &GeoInfo{Name: "europe", Codes: []string{"EUR"}}},
+			{GeoInfo: &GeoInfo{Name: "france", Codes: []string{"eur", "Fr", "ZZ"}}},
+		},
+	}}
+	err := rr.Validate()
+	if err == nil || !strings.Contains(err.Error(), `code "EUR" is in geo groups europe and france`) || !strings.Contains(err.Error(), `geo group france: unknown geo code "ZZ"`) || strings.Contains(err.Error(), `"Fr"`) {
+		t.Errorf("Validate: %v", err)
+	}
+}
+
+func Test_GeoDirectionalPoolsService_Create_Validation(t *testing.T) {
+	hits := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	testClient.ValidateGeoGroups = true
+	k := GeoDirectionalPoolKey{Account: "test", Name: "pool"}
+
+	if _, err := testClient.DirectionalPools.Geos().Create(k, AccountLevelGeoDirectionalGroupDTO{Name: "pool", Codes: []string{"US, UK"}}); !IsValidation(err) {
+		t.Errorf("Create: %v, want a validation error", err)
+	}
+	if _, err := testClient.DirectionalPools.Geos().Update(k, AccountLevelGeoDirectionalGroupDTO{Name: "pool", Codes: []string{"ZZ"}}); !IsValidation(err) {
+		t.Errorf("Update: %v, want a validation error", err)
+	}
+	if _, err := testClient.DirectionalPools.Geos().Create(k, AccountLevelGeoDirectionalGroupDTO{Name: "pool", Codes: []string{"US", "GB"}}); err != nil {
+		t.Errorf("Create: %v", err)
+	}
+	testClient.ValidateGeoGroups = false
+	if _, err := testClient.DirectionalPools.Geos().Create(k, AccountLevelGeoDirectionalGroupDTO{Name: "pool", Codes: []string{"ZZ"}}); err != nil {
+		t.Errorf("Create without validation: %v", err)
+	}
+	if hits != 2 {
+		t.Errorf("requests: %d, want: %d", hits, 2)
+	}
+}
@@ -0,0 +1,321 @@
+package udnssdk
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// IPRange is an inclusive range of addresses of one family
+type IPRange struct {
+	From netip.Addr
+	To   netip.Addr
+}
+
+// NewIPRange returns the range from and to, which must be of the same family and in order; IPv4-mapped IPv6 addresses are unmapped
+func NewIPRange(from, to netip.Addr) (IPRange, error) {
+	from, to = from.Unmap(), to.Unmap()
+	if !from.IsValid() || !to.IsValid() || from.BitLen() != to.BitLen() {
+		return IPRange{}, fmt.Errorf("invalid range %s-%s", from, to)
+	}
+	if to.Less(from) {
+		return IPRange{}, fmt.Errorf("range %s-%s ends before it starts", from, to)
+	}
+	return IPRange{From: from, To: to}, nil
+}
+
+// IPRangeFromPrefix returns the range of the addresses of p
+func IPRangeFromPrefix(p netip.Prefix) IPRange {
+	if a := p.Addr(); a.Is4In6() && p.Bits() >= 96 {
+		p = netip.PrefixFrom(a.Unmap(), p.Bits()-96)
+	}
+	p = p.Masked()
+	return IPRange{From: p.Addr(), To: lastAddr(p)}
+}
+
+// Contains reports whether a is in r
+func (r IPRange) Contains(a netip.Addr) bool {
+	a = a.Unmap()
+	return a.BitLen() == r.From.BitLen() && !a.Less(r.From) && !r.To.Less(a)
+}
+
+// Overlaps reports whether r and o have addresses in common
+func (r IPRange) Overlaps(o IPRange) bool {
+	return r.From.BitLen() == o.From.BitLen() && !r.To.Less(o.From) && !o.To.Less(r.From)
+}
+
+// Intersect returns the addresses r and o have in common; ok is false if they have none
+func (r IPRange) Intersect(o IPRange) (i IPRange, ok bool) {
+	if !r.Overlaps(o) {
+		return IPRange{}, false
+	}
+	i = r
+	if i.From.Less(o.From) {
+		i.From = o.From
+	}
+	if o.To.Less(i.To) {
+		i.To = o.To
+	}
+	return i, true
+}
+
+// size returns the number of addresses of r, less one
+func (r IPRange) size() *big.Int {
+	return new(big.Int).Sub(addrInt(r.To), addrInt(r.From))
+}
+
+// Prefix returns the prefix of r, if it is exactly one
+func (r IPRange) Prefix() (netip.Prefix, bool) {
+	for bits := r.From.BitLen(); bits >= 0; bits-- {
+		p := netip.PrefixFrom(r.From, bits)
+		if p.Masked().Addr() != r.From {
+			break
+		}
+		if lastAddr(p) == r.To {
+			return p, true
+		}
+	}
+	return netip.Prefix{}, false
+}
+
+// Prefixes returns the shortest list of prefixes covering r, in order
+func (r IPRange) Prefixes() []netip.Prefix {
+	prefixes := []netip.Prefix{}
+	from := r.From
+	for {
+		p := netip.PrefixFrom(from, from.BitLen())
+		for bits := from.BitLen() - 1; bits >= 0; bits-- {
+			wider := netip.PrefixFrom(from, bits)
+			if wider.Masked().Addr() != from || r.To.Less(lastAddr(wider)) {
+				break
+			}
+			p = wider
+		}
+		prefixes = append(prefixes, p)
+		last := lastAddr(p)
+		if last == r.To {
+			return prefixes
+		}
+		from = last.Next()
+	}
+}
+
+// String returns r as a prefix if it is one, or as "from-to"
+func (r IPRange) String() string {
+	if p, ok := r.Prefix(); ok {
+		return p.String()
+	}
+	return fmt.Sprintf("%s-%s", r.From, r.To)
+}
+
+// Validate checks that d uses exactly one of its forms, an Address, a CIDR, or a Start and an End, and that it parses
+func (d IPAddrDTO) Validate() error {
+	forms := []string{}
+	if d.Address != "" {
+		forms = append(forms, "address")
+	}
+	if d.CIDR != "" {
+		forms = append(forms, "cidr")
+	}
+	if d.Start != "" || d.End != "" {
+		forms = append(forms, "start/end")
+	}
+	switch len(forms) {
+	case 0:
+		return fmt.Errorf("empty IP range")
+	case 1:
+	default:
+		return fmt.Errorf("IP range %+v sets %s; it must set only one of them", d, strings.Join(forms, " and "))
+	}
+	_, err := d.Range()
+	return err
+}
+
+// Range returns the range of addresses d describes
+func (d IPAddrDTO) Range() (IPRange, error) {
+	switch {
+	case d.CIDR != "":
+		p, err := netip.ParsePrefix(d.CIDR)
+		if err != nil {
+			return IPRange{}, err
+		}
+		return IPRangeFromPrefix(p), nil
+	case d.Address != "":
+		a, err := netip.ParseAddr(d.Address)
+		if err != nil {
+			return IPRange{}, err
+		}
+		return NewIPRange(a, a)
+	case d.Start != "" || d.End != "":
+		if d.Start == "" || d.End == "" {
+			return IPRange{}, fmt.Errorf("IP range %+v needs both a start and an end", d)
+		}
+		from, err := netip.ParseAddr(d.Start)
+		if err != nil {
+			return IPRange{}, err
+		}
+		to, err := netip.ParseAddr(d.End)
+		if err != nil {
+			return IPRange{}, err
+		}
+		return NewIPRange(from, to)
+	}
+	return IPRange{}, fmt.Errorf("empty IP range")
+}
+
+// IPAddrDTOFromPrefix returns the IPAddrDTO of p, as a CIDR
+func IPAddrDTOFromPrefix(p netip.Prefix) IPAddrDTO {
+	return IPAddrDTO{CIDR: p.Masked().String()}
+}
+
+// IPAddrDTOFromRange returns the IPAddrDTO of r: a CIDR if r is a prefix, single addresses included, or a Start and an End
+func IPAddrDTOFromRange(r IPRange) IPAddrDTO {
+	if p, ok := r.Prefix(); ok {
+		return IPAddrDTOFromPrefix(p)
+	}
+	return IPAddrDTO{Start: r.From.String(), End: r.To.String()}
+}
+
+// NormalizeIPAddrs merges the overlapping and adjacent ranges of dtos, and returns them in order,
+// IPv4 first, each as a CIDR if it is a prefix, single addresses as /32 or /128, or as a Start and an End
+func NormalizeIPAddrs(dtos []IPAddrDTO) ([]IPAddrDTO, error) {
+	ranges := make([]IPRange, 0, len(dtos))
+	for _, d := range dtos {
+		r, err := d.Range()
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	normalized := []IPAddrDTO{}
+	for _, r := range mergeIPRanges(ranges) {
+		normalized = append(normalized, IPAddrDTOFromRange(r))
+	}
+	return normalized, nil
+}
+
+// mergeIPRanges merges the overlapping and adjacent ranges, in order, IPv4 first
+func mergeIPRanges(ranges []IPRange) []IPRange {
+	sorted := append([]IPRange{}, ranges...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].From.BitLen() != sorted[j].From.BitLen() {
+			return sorted[i].From.BitLen() < sorted[j].From.BitLen()
+		}
+		return sorted[i].From.Less(sorted[j].From)
+	})
+	merged := []IPRange{}
+	for _, r := range sorted {
+		if n := len(merged); n != 0 {
+			last := &merged[n-1]
+			next := last.To.Next()
+			if last.From.BitLen() == r.From.BitLen() && (last.Overlaps(r) || (next.IsValid() && next == r.From)) {
+				if last.To.Less(r.To) {
+					last.To = r.To
+				}
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// Validate checks each IP range of the group
+func (g AccountLevelIPDirectionalGroupDTO) Validate() error {
+	problems := []string{}
+	if len(g.IPs) == 0 {
+		problems = append(problems, "at least one IP range is required")
+	}
+	for _, d := range g.IPs {
+		if err := d.Validate(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if len(problems) != 0 {
+		return &IPGroupValidationError{Name: g.Name, Problems: problems}
+	}
+	return nil
+}
+
+// IPGroupValidationError lists the problems Validate found with the ranges of an IP group.
+// It matches ErrValidation, like the API's own validation errors.
+type IPGroupValidationError struct {
+	Name     string
+	Problems []string
+}
+
+func (e *IPGroupValidationError) Error() string {
+	return fmt.Sprintf("invalid IP group %s: %s", e.Name, strings.Join(e.Problems, "; "))
+}
+
+// Is reports whether target is ErrValidation
+func (e *IPGroupValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// IPGroupOverlap is a range of addresses held by two IP groups, which UltraDNS routes to either of them
+type IPGroupOverlap struct {
+	Groups [2]string
+	Range  IPRange
+}
+
+// FindIPGroupOverlaps returns the ranges held by more than one of groups, for each pair of groups in their order
+func FindIPGroupOverlaps(groups []AccountLevelIPDirectionalGroupDTO) ([]IPGroupOverlap, error) {
+	ranges := make([][]IPRange, len(groups))
+	for i, g := range groups {
+		for _, d := range g.IPs {
+			r, err := d.Range()
+			if err != nil {
+				return nil, fmt.Errorf("IP group %s: %w", g.Name, err)
+			}
+			ranges[i] = append(ranges[i], r)
+		}
+		ranges[i] = mergeIPRanges(ranges[i])
+	}
+
+	overlaps := []IPGroupOverlap{}
+	for i := range groups {
+		for j := i + 1; j < len(groups); j++ {
+			for _, a := range ranges[i] {
+				for _, b := range ranges[j] {
+					if r, ok := a.Intersect(b); ok {
+						overlaps = append(overlaps, IPGroupOverlap{Groups: [2]string{groups[i].Name, groups[j].Name}, Range: r})
+					}
+				}
+			}
+		}
+	}
+	return overlaps, nil
+}
+
+// Overlaps returns the ranges held by more than one of the IP groups of the account k
+func (s *IPDirectionalPoolsService) Overlaps(k AccountKey) ([]IPGroupOverlap, error) {
+	return s.OverlapsContext(context.Background(), k)
+}
+
+// OverlapsContext is Overlaps with a context.Context
+func (s *IPDirectionalPoolsService) OverlapsContext(ctx context.Context, k AccountKey) ([]IPGroupOverlap, error) {
+	groups, err := s.SelectContext(ctx, IPDirectionalPoolKey{Account: k}, "")
+	if err != nil {
+		return nil, err
+	}
+	return FindIPGroupOverlaps(groups)
+}
+
+// lastAddr returns the last address of p, which must be masked
+func lastAddr(p netip.Prefix) netip.Addr {
+	b := p.Addr().AsSlice()
+	for i := p.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 0x80 >> (i % 8)
+	}
+	a, _ := netip.AddrFromSlice(b)
+	return a
+}
+
+// addrInt returns a as an integer
+func addrInt(a netip.Addr) *big.Int {
+	return new(big.Int).SetBytes(a.AsSlice())
+}
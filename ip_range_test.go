@@ -0,0 +1,136 @@
+package udnssdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func Test_IPAddrDTO_Range(t *testing.T) {
+	cases := []struct {
+		dto  IPAddrDTO
+		want string
+	}{
+		{IPAddrDTO{CIDR: "192.0.2.17/24"}, "192.0.2.0/24"},
+		{IPAddrDTO{Address: "192.0.2.1"}, "192.0.2.1/32"},
+		{IPAddrDTO{Address: "2001:db8::1"}, "2001:db8::1/128"},
+		{IPAddrDTO{Address: "::ffff:192.0.2.1"}, "192.0.2.1/32"},
+		{IPAddrDTO{Start: "192.0.2.0", End: "192.0.2.127"}, "192.0.2.0/25"},
+		{IPAddrDTO{Start: "192.0.2.1", End: "192.0.2.9"}, "192.0.2.1-192.0.2.9"},
+	}
+	for _, c := range cases {
+		r, err := c.dto.Range()
+		if err != nil {
+			t.Errorf("Range(%+v): %v", c.dto, err)
+			continue
+		}
+		if r.String() != c.want {
+			t.Errorf("Range(%+v): %s, want: %s", c.dto, r, c.want)
+		}
+	}
+
+	for _, d := range []IPAddrDTO{
+		{},
+		{Address: "192.0.2.1", CIDR: "192.0.2.0/24"},
+		{Start: "192.0.2.1"},
+		{Start: "192.0.2.9", End: "192.0.2.1"},
+		{Start: "192.0.2.1", End: "2001:db8::1"},
+		{CIDR: "192.0.2.0/33"},
+	} {
+		if err := d.Validate(); err == nil {
+			t.Errorf("Validate(%+v): no error", d)
+		}
+	}
+}
+
+func Test_IPRange_Prefixes(t *testing.T) {
+	r, _ := NewIPRange(netip.MustParseAddr("192.0.2.1"), netip.MustParseAddr("192.0.2.9"))
+	want := []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.1/32"),
+		netip.MustParsePrefix("192.0.2.2/31"),
+		netip.MustParsePrefix("192.0.2.4/30"),
+		netip.MustParsePrefix("192.0.2.8/31"),
+	}
+	if got := r.Prefixes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Prefixes: %v, want: %v", got, want)
+	}
+	all := IPRangeFromPrefix(netip.MustParsePrefix("0.0.0.0/0"))
+	if got := all.Prefixes(); len(got) != 1 || got[0].Bits() != 0 {
+		t.Errorf("Prefixes(0.0.0.0/0): %v", got)
+	}
+	if !r.Contains(netip.MustParseAddr("::ffff:192.0.2.5")) || r.Contains(netip.MustParseAddr("192.0.2.10")) {
+		t.Errorf("Contains: wrong for %s", r)
+	}
+}
+
+func Test_NormalizeIPAddrs(t *testing.T) {
+	got, err := NormalizeIPAddrs([]IPAddrDTO{
+		{Address: "2001:db8::1"},
+		{Start: "192.0.2.128", End: "192.0.2.255"},
+		{CIDR: "192.0.2.0/25"},
+		{Address: "198.51.100.7"},
+		{Start: "198.51.100.8", End: "198.51.100.10"},
+		{CIDR: "192.0.2.64/26"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []IPAddrDTO{
+		{CIDR: "192.0.2.0/24"},
+		{Start: "198.51.100.7", End: "198.51.100.10"},
+		{CIDR: "2001:db8::1/128"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeIPAddrs: %+v, want: %+v", got, want)
+	}
+}
+
+func Test_FindIPGroupOverlaps(t *testing.T) {
+	groups := []AccountLevelIPDirectionalGroupDTO{
+		{Name: "office", IPs: []IPAddrDTO{{CIDR: "198.51.100.0/24"}, {Address: "2001:db8::1"}}},
+		{Name: "lab", IPs: []IPAddrDTO{{Start: "198.51.100.200", End: "198.51.101.10"}}},
+		{Name: "home", IPs: []IPAddrDTO{{CIDR: "2001:db8::/64"}, {Address: "203.0.113.1"}}},
+	}
+	got, err := FindIPGroupOverlaps(groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []IPGroupOverlap{
+		{Groups: [2]string{"office", "lab"}, Range: IPRange{From: netip.MustParseAddr("198.51.100.200"), To: netip.MustParseAddr("198.51.100.255")}},
+		{Groups: [2]string{"office", "home"}, Range: IPRange{From: netip.MustParseAddr("2001:db8::1"), To: netip.MustParseAddr("2001:db8::1")}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindIPGroupOverlaps: %+v, want: %+v", got, want)
+	}
+}
+
+func Test_IPDirectionalPoolsService_Overlaps(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ipGroups":[` +
+			`{"name":"a","ips":[{"cidr":"10.0.0.0/8"}]},` +
+			`{"name":"b","ips":[{"address":"10.1.2.3"}]}],` +
+			`"resultInfo":{"totalCount":2,"offset":0,"returnedCount":2}}`))
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	got, err := testClient.DirectionalPools.IPs().Overlaps(AccountKey("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Groups != [2]string{"a", "b"} || got[0].Range.String() != "10.1.2.3/32" {
+		t.Errorf("Overlaps: %+v", got)
+	}
+
+	k := IPDirectionalPoolKey{Account: "test", Name: "c"}
+	g := AccountLevelIPDirectionalGroupDTO{Name: "c", IPs: []IPAddrDTO{{Address: "10.0.0.1", CIDR: "10.0.0.0/24"}}}
+	if _, err := testClient.DirectionalPools.IPs().Create(k, g); err != nil {
+		t.Errorf("Create without validation: %v", err)
+	}
+	testClient.ValidateIPGroups = true
+	if _, err := testClient.DirectionalPools.IPs().Create(k, g); !IsValidation(err) {
+		t.Errorf("Create: %v, want a validation error", err)
+	}
+}
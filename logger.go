@@ -0,0 +1,232 @@
+package udnssdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Logger receives the Client's diagnostic messages with alternating key-value pairs.
+// A *slog.Logger satisfies Logger; see also NewSlogLogger and NewStdLogger.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// redacted replaces secrets in logged headers and bodies
+const redacted = "REDACTED"
+
+// NopLogger returns a Logger which discards every message. It is the Client's default.
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// NewSlogLogger returns a Logger writing to l, or to slog.Default() if l is nil
+func NewSlogLogger(l *slog.Logger) Logger {
+	return slogLogger{l: l}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s slogLogger) logger() *slog.Logger {
+	if s.l == nil {
+		return slog.Default()
+	}
+	return s.l
+}
+
+func (s slogLogger) Debug(msg string, kv ...interface{}) { s.logger().Debug(msg, kv...) }
+func (s slogLogger) Info(msg string, kv ...interface{})  { s.logger().Info(msg, kv...) }
+func (s slogLogger) Warn(msg string, kv ...interface{})  { s.logger().Warn(msg, kv...) }
+func (s slogLogger) Error(msg string, kv ...interface{}) { s.logger().Error(msg, kv...) }
+
+// NewStdLogger returns a Logger writing lines like "[DEBUG] msg key=value" to l,
+// or to the standard logger if l is nil. The level prefixes suit hashicorp/logutils filters.
+func NewStdLogger(l *log.Logger) Logger {
+	return stdLogger{l: l}
+}
+
+type stdLogger struct {
+	l *log.Logger
+}
+
+func (s stdLogger) output(level, msg string, kv []interface{}) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s", level, msg)
+	for i := 0; i < len(kv); i += 2 {
+		if i+1 < len(kv) {
+			fmt.Fprintf(&b, " %v=%+v", kv[i], kv[i+1])
+		} else {
+			fmt.Fprintf(&b, " %+v", kv[i])
+		}
+	}
+	if s.l == nil {
+		log.Print(b.String())
+		return
+	}
+	s.l.Print(b.String())
+}
+
+func (s stdLogger) Debug(msg string, kv ...interface{}) { s.output("DEBUG", msg, kv) }
+func (s stdLogger) Info(msg string, kv ...interface{})  { s.output("INFO", msg, kv) }
+func (s stdLogger) Warn(msg string, kv ...interface{})  { s.output("WARN", msg, kv) }
+func (s stdLogger) Error(msg string, kv ...interface{}) { s.output("ERROR", msg, kv) }
+
+// logger returns the Client's Logger, or a NopLogger if it has none
+func (c *Client) logger() Logger {
+	if c.Logger == nil {
+		return nopLogger{}
+	}
+	return c.Logger
+}
+
+// logResultInfo logs the list metadata of a page of results
+func (c *Client) logResultInfo(ri ResultInfo) {
+	c.logger().Debug("ResultInfo", "totalCount", ri.TotalCount, "offset", ri.Offset, "returnedCount", ri.ReturnedCount)
+}
+
+// logRequest logs req with secrets redacted, including its body if the Client logs bodies
+func (c *Client) logRequest(req *http.Request) {
+	kv := []interface{}{"method", req.Method, "url", req.URL.String(), "header", redactHeader(req.Header)}
+	if c.LogBodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := ioutil.ReadAll(body)
+			body.Close()
+			kv = append(kv, "body", redactBody(req.Header.Get("Content-Type"), b))
+		}
+	}
+	c.logger().Debug("HTTP Request", kv...)
+}
+
+// logResponse logs r with secrets redacted, including its body if the Client logs bodies.
+// The body is buffered and replaced so it can still be read by the caller.
+func (c *Client) logResponse(r *http.Response, err error) {
+	if err != nil {
+		c.logger().Debug("HTTP Response", "error", err)
+		return
+	}
+	kv := []interface{}{"status", r.Status, "header", redactHeader(r.Header)}
+	if c.LogBodies && r.Body != nil {
+		b, rerr := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(b))
+		if rerr == nil {
+			kv = append(kv, "body", redactBody(r.Header.Get("Content-Type"), b))
+		}
+	}
+	c.logger().Debug("HTTP Response", kv...)
+}
+
+// tokenLoggingTransport logs OAuth2 token requests and responses through a Client's Logger
+type tokenLoggingTransport struct {
+	client *Client
+	base   http.RoundTripper
+}
+
+// RoundTrip logs and sends the token request
+func (t *tokenLoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.client.logRequest(req)
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	r, err := base.RoundTrip(req)
+	t.client.logResponse(r, err)
+	return r, err
+}
+
+// sensitiveHeaders are never logged
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveFields are redacted from logged JSON and form bodies, compared case-insensitively
+var sensitiveFields = map[string]bool{
+	"password":      true,
+	"client_secret": true,
+	"access_token":  true,
+	"accesstoken":   true,
+	"refresh_token": true,
+	"refreshtoken":  true,
+	"tsigkeyvalue":  true,
+}
+
+// redactHeader returns a copy of h with sensitive headers redacted
+func redactHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range sensitiveHeaders {
+		if _, ok := out[k]; ok {
+			out.Set(k, redacted)
+		}
+	}
+	return out
+}
+
+// redactBody returns b as a string with sensitive JSON or form fields redacted
+func redactBody(contentType string, b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		v, err := url.ParseQuery(string(b))
+		if err != nil {
+			return redacted
+		}
+		for k := range v {
+			if sensitiveFields[strings.ToLower(k)] {
+				v.Set(k, redacted)
+			}
+		}
+		return v.Encode()
+	}
+
+	var j interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&j); err != nil {
+		if err == io.EOF {
+			return ""
+		}
+		return string(b)
+	}
+	out, err := json.Marshal(redactJSON(j))
+	if err != nil {
+		return redacted
+	}
+	return string(out)
+}
+
+// redactJSON walks a decoded JSON value redacting sensitive object members
+func redactJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			if sensitiveFields[strings.ToLower(k)] {
+				t[k] = redacted
+			} else {
+				t[k] = redactJSON(e)
+			}
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = redactJSON(e)
+		}
+	}
+	return v
+}
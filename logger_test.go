@@ -0,0 +1,99 @@
+package udnssdk
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_Logger_RedactsSecrets(t *testing.T) {
+	ts, _ := newAuthServer(t, nil)
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	l := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c, err := NewClientWithOptions(
+		WithBaseURL(ts.URL),
+		WithCredentials("samdoe", "s3cr3t"),
+		WithClientCredentials("app", "shh-secret"),
+		WithLogger(NewSlogLogger(l)),
+		WithBodyLogging(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := c.Accounts.Select(); err != nil {
+		t.Fatal(err)
+	}
+	p := ProbeInfoDTO{
+		ProbeType: FTPProbeType,
+		Details: &ProbeDetailsDTO{
+			Detail: FTPProbeDetailsDTO{Username: "ftp", Password: "hunter2", Path: "/"},
+		},
+	}
+	c.Probes.Create(RRSetKey{Zone: "basedomain.example", Type: "A", Name: "foo"}, p)
+
+	out := buf.String()
+	for _, secret := range []string{"s3cr3t", "shh-secret", "hunter2", "Bearer issued", `"issued"`} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log contains %q:\n%s", secret, out)
+		}
+	}
+	for _, want := range []string{"HTTP Request", "HTTP Response", "samdoe", redacted, `\"username\":\"ftp\"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func Test_Logger_DefaultIsSilent(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	ts, _ := newAuthServer(t, nil)
+	defer ts.Close()
+	c, _ := NewClient("samdoe", "s3cr3t", ts.URL)
+	c.Accounts.Select()
+
+	if buf.Len() != 0 {
+		t.Errorf("standard logger received: %s", buf.String())
+	}
+}
+
+func Test_NewStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewStdLogger(log.New(&buf, "", 0))
+
+	l.Warn("Retrying request", "method", "GET", "attempt", 2)
+
+	want := "[WARN] Retrying request method=GET attempt=2\n"
+	if buf.String() != want {
+		t.Errorf("output: %q, want: %q", buf.String(), want)
+	}
+}
+
+func Test_FTPProbeDetailsDTO_RedactsPassword(t *testing.T) {
+	d := FTPProbeDetailsDTO{Username: "ftp", Password: "hunter2", Path: "/"}
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		out := fmt.Sprintf(format, d)
+		if strings.Contains(out, "hunter2") {
+			t.Errorf("Sprintf(%q): %s", format, out)
+		}
+	}
+	if d.Password != "hunter2" {
+		t.Errorf("Password was modified: %q", d.Password)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("probe", "details", d)
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("slog: %s", buf.String())
+	}
+}
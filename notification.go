@@ -1,10 +1,9 @@
 package udnssdk
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"net/http"
-	"time"
 )
 
 // NotificationsService manages Probes
@@ -62,48 +61,63 @@ func (k NotificationKey) URI() string {
 
 // Select requests all notifications by RRSetKey and optional query, using pagination and error handling
 func (s *NotificationsService) Select(k RRSetKey, query string) ([]NotificationDTO, *http.Response, error) {
-	// TODO: Sane Configuration for timeouts / retries
-	maxerrs := 5
-	waittime := 5 * time.Second
+	return s.SelectContext(context.Background(), k, query)
+}
 
-	// init accumulators
-	pis := []NotificationDTO{}
-	errcnt := 0
-	offset := 0
-
-	for {
-		reqNotifications, ri, res, err := s.SelectWithOffset(k, query, offset)
-		if err != nil {
-			if res != nil && res.StatusCode >= 500 {
-				errcnt = errcnt + 1
-				if errcnt < maxerrs {
-					time.Sleep(waittime)
-					continue
-				}
-			}
-			return pis, res, err
-		}
-
-		log.Printf("[DEBUG] ResultInfo: %+v\n", ri)
-		for _, pi := range reqNotifications {
-			pis = append(pis, pi)
-		}
-		if ri.ReturnedCount+ri.Offset >= ri.TotalCount {
-			return pis, res, nil
-		}
-		offset = ri.ReturnedCount + ri.Offset
-		continue
-	}
+// SelectContext is Select with a context.Context
+func (s *NotificationsService) SelectContext(ctx context.Context, k RRSetKey, query string) ([]NotificationDTO, *http.Response, error) {
+	p := NewPager(s.client, func(ctx context.Context, _ QueryInfo, offset int) ([]NotificationDTO, ResultInfo, *http.Response, error) {
+		return s.SelectWithOffsetContext(ctx, k, query, offset)
+	})
+	pis, err := p.Collect(ctx)
+	return pis, p.Response(), err
 }
 
 // SelectWithOffset requests list of notifications by RRSetKey, query and offset, also returning list metadata, the actual response, or an error
 func (s *NotificationsService) SelectWithOffset(k RRSetKey, query string, offset int) ([]NotificationDTO, ResultInfo, *http.Response, error) {
+	return s.SelectWithOffsetContext(context.Background(), k, query, offset)
+}
+
+// SelectWithOffsetContext is SelectWithOffset with a context.Context
+func (s *NotificationsService) SelectWithOffsetContext(ctx context.Context, k RRSetKey, query string, offset int) ([]NotificationDTO, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, k.NotificationsQueryURI(query, offset))
+}
+
+// SelectQuery requests all notifications by RRSetKey matching q, using pagination and error handling
+func (s *NotificationsService) SelectQuery(k RRSetKey, q Query) ([]NotificationDTO, *http.Response, error) {
+	return s.SelectQueryContext(context.Background(), k, q)
+}
+
+// SelectQueryContext is SelectQuery with a context.Context
+func (s *NotificationsService) SelectQueryContext(ctx context.Context, k RRSetKey, q Query) ([]NotificationDTO, *http.Response, error) {
+	p := s.Pager(k, q)
+	pis, err := p.Collect(ctx)
+	return pis, p.Response(), err
+}
+
+// SelectQueryWithOffset requests list of notifications by RRSetKey matching q, starting at offset, also returning list metadata, the actual response, or an error
+func (s *NotificationsService) SelectQueryWithOffset(k RRSetKey, q Query, offset int) ([]NotificationDTO, ResultInfo, *http.Response, error) {
+	return s.SelectQueryWithOffsetContext(context.Background(), k, q, offset)
+}
+
+// SelectQueryWithOffsetContext is SelectQueryWithOffset with a context.Context
+func (s *NotificationsService) SelectQueryWithOffsetContext(ctx context.Context, k RRSetKey, q Query, offset int) ([]NotificationDTO, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, q.URI(k.NotificationsURI(), offset))
+}
+
+// Pager returns a Pager over the notifications of k matching q
+func (s *NotificationsService) Pager(k RRSetKey, q Query) *Pager[NotificationDTO] {
+	return newQueryPager(s.client, q, func(ctx context.Context, q Query, offset int) ([]NotificationDTO, ResultInfo, *http.Response, error) {
+		return s.SelectQueryWithOffsetContext(ctx, k, q, offset)
+	})
+}
+
+// selectURI requests a page of notifications from uri
+func (s *NotificationsService) selectURI(ctx context.Context, uri string) ([]NotificationDTO, ResultInfo, *http.Response, error) {
 	var tld NotificationListDTO
 
-	uri := k.NotificationsQueryURI(query, offset)
-	res, err := s.client.get(uri, &tld)
+	res, err := s.client.get(ctx, uri, &tld)
 
-	log.Printf("DEBUG - ResultInfo: %+v\n", tld.Resultinfo)
 	pis := []NotificationDTO{}
 	for _, pi := range tld.Notifications {
 		pis = append(pis, pi)
@@ -113,22 +127,42 @@ func (s *NotificationsService) SelectWithOffset(k RRSetKey, query string, offset
 
 // Find requests a notification by NotificationKey,returning the actual response, or an error
 func (s *NotificationsService) Find(k NotificationKey) (NotificationDTO, *http.Response, error) {
+	return s.FindContext(context.Background(), k)
+}
+
+// FindContext is Find with a context.Context
+func (s *NotificationsService) FindContext(ctx context.Context, k NotificationKey) (NotificationDTO, *http.Response, error) {
 	var t NotificationDTO
-	res, err := s.client.get(k.URI(), &t)
+	res, err := s.client.get(ctx, k.URI(), &t)
 	return t, res, err
 }
 
 // Create requests creation of an event by RRSetKey, with provided NotificationInfoDTO, returning actual response or an error
 func (s *NotificationsService) Create(k NotificationKey, n NotificationDTO) (*http.Response, error) {
-	return s.client.post(k.URI(), n, nil)
+	return s.CreateContext(context.Background(), k, n)
+}
+
+// CreateContext is Create with a context.Context
+func (s *NotificationsService) CreateContext(ctx context.Context, k NotificationKey, n NotificationDTO) (*http.Response, error) {
+	return s.client.post(ctx, k.URI(), n, nil)
 }
 
 // Update requests update of an event by NotificationKey, with provided NotificationInfoDTO, returning the actual response or an error
 func (s *NotificationsService) Update(k NotificationKey, n NotificationDTO) (*http.Response, error) {
-	return s.client.put(k.URI(), n, nil)
+	return s.UpdateContext(context.Background(), k, n)
+}
+
+// UpdateContext is Update with a context.Context
+func (s *NotificationsService) UpdateContext(ctx context.Context, k NotificationKey, n NotificationDTO) (*http.Response, error) {
+	return s.client.put(ctx, k.URI(), n, nil)
 }
 
 // Delete requests deletion of an event by NotificationKey, returning the actual response or an error
 func (s *NotificationsService) Delete(k NotificationKey) (*http.Response, error) {
-	return s.client.delete(k.URI(), nil)
+	return s.DeleteContext(context.Background(), k)
+}
+
+// DeleteContext is Delete with a context.Context
+func (s *NotificationsService) DeleteContext(ctx context.Context, k NotificationKey) (*http.Response, error) {
+	return s.client.delete(ctx, k.URI(), nil)
 }
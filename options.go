@@ -0,0 +1,233 @@
+package udnssdk
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	oauthPassword "github.com/terra-farm/udnssdk/password"
+)
+
+// Option configures a Client built by NewClientWithOptions
+type Option func(*clientOptions)
+
+// clientOptions collects the settings applied by Options
+type clientOptions struct {
+	username        string
+	password        string
+	baseURL         string
+	clientID        string
+	clientSecret    string
+	scopes          []string
+	httpClient      *http.Client
+	transport       http.RoundTripper
+	timeout         time.Duration
+	userAgentSuffix string
+	token           *oauth2.Token
+	logger          Logger
+	logBodies       bool
+	retryPolicy     RetryPolicy
+	taskWaiter      TaskWaiter
+	validateRRSets  bool
+	validateGeo     bool
+	validateIPs     bool
+	validateProbes  bool
+}
+
+// WithCredentials sets the UltraDNS username and password
+func WithCredentials(username, password string) Option {
+	return func(o *clientOptions) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithBaseURL sets the REST API base URL, DefaultLiveBaseURL by default
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithClientCredentials sets the OAuth2 client ID and secret sent with token requests
+func WithClientCredentials(clientID, clientSecret string) Option {
+	return func(o *clientOptions) {
+		o.clientID = clientID
+		o.clientSecret = clientSecret
+	}
+}
+
+// WithScopes sets the OAuth2 scopes requested with tokens
+func WithScopes(scopes ...string) Option {
+	return func(o *clientOptions) {
+		o.scopes = scopes
+	}
+}
+
+// WithToken seeds the Client with a previously obtained token,
+// which is used until it expires before the credentials are.
+func WithToken(t *oauth2.Token) Option {
+	return func(o *clientOptions) {
+		o.token = t
+	}
+}
+
+// WithHTTPClient bases the Client's HTTP client on hc.
+// hc is copied, not modified; its Transport is wrapped to add authorization.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(o *clientOptions) {
+		o.httpClient = hc
+	}
+}
+
+// WithTransport sets the RoundTripper used for API and token requests,
+// taking precedence over the Transport of a client given to WithHTTPClient.
+func WithTransport(t http.RoundTripper) Option {
+	return func(o *clientOptions) {
+		o.transport = t
+	}
+}
+
+// WithTimeout sets the time limit of each HTTP request, including the token request
+func WithTimeout(d time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = d
+	}
+}
+
+// WithUserAgent appends suffix to the User-Agent header sent with every request
+func WithUserAgent(suffix string) Option {
+	return func(o *clientOptions) {
+		o.userAgentSuffix = suffix
+	}
+}
+
+// WithLogger sets the Logger used for the Client's diagnostic messages, including token requests
+func WithLogger(l Logger) Option {
+	return func(o *clientOptions) {
+		o.logger = l
+	}
+}
+
+// WithBodyLogging includes request and response bodies, with secrets redacted, in logged messages
+func WithBodyLogging(enabled bool) Option {
+	return func(o *clientOptions) {
+		o.logBodies = enabled
+	}
+}
+
+// WithRetryPolicy sets the Client's RetryPolicy
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = p
+	}
+}
+
+// WithTaskWaiter sets the Client's TaskWaiter
+func WithTaskWaiter(w TaskWaiter) Option {
+	return func(o *clientOptions) {
+		o.taskWaiter = w
+	}
+}
+
+// WithRRSetValidation validates RRSets with RRSet.Validate before they are created or updated
+func WithRRSetValidation(enabled bool) Option {
+	return func(o *clientOptions) {
+		o.validateRRSets = enabled
+	}
+}
+
+// WithGeoCodeValidation checks the codes of geo directional groups with AccountLevelGeoDirectionalGroupDTO.Validate
+// before they are created or updated
+func WithGeoCodeValidation(enabled bool) Option {
+	return func(o *clientOptions) {
+		o.validateGeo = enabled
+	}
+}
+
+// WithIPGroupValidation checks the ranges of IP directional groups with AccountLevelIPDirectionalGroupDTO.Validate
+// before they are created or updated
+func WithIPGroupValidation(enabled bool) Option {
+	return func(o *clientOptions) {
+		o.validateIPs = enabled
+	}
+}
+
+// WithProbeValidation checks probes with ProbeInfoDTO.Validate before they are created or updated
+func WithProbeValidation(enabled bool) Option {
+	return func(o *clientOptions) {
+		o.validateProbes = enabled
+	}
+}
+
+// NewClientWithOptions returns a new ultradns API client configured by opts.
+func NewClientWithOptions(opts ...Option) (*Client, error) {
+	o := clientOptions{
+		baseURL:     DefaultLiveBaseURL,
+		retryPolicy: DefaultRetryPolicy(),
+		taskWaiter:  DefaultTaskWaiter(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	u, err := url.Parse(o.baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := NewConfig(o.username, o.password, o.baseURL)
+	conf.ClientID = o.clientID
+	conf.ClientSecret = o.clientSecret
+	conf.Scopes = o.scopes
+
+	hc := &http.Client{}
+	if o.httpClient != nil {
+		*hc = *o.httpClient
+	}
+	if o.timeout > 0 {
+		hc.Timeout = o.timeout
+	}
+	base := hc.Transport
+	if o.transport != nil {
+		base = o.transport
+	}
+	t := &oauthPassword.Transport{
+		Config: conf,
+		Base:   base,
+	}
+	if o.token != nil {
+		t.SetToken(o.token)
+	}
+	hc.Transport = t
+
+	ua := userAgent
+	if o.userAgentSuffix != "" {
+		ua += " " + o.userAgentSuffix
+	}
+
+	c := &Client{
+		HTTPClient: hc,
+		BaseURL:    u,
+		UserAgent:  ua,
+		Config:     conf,
+		Logger:     o.logger,
+		LogBodies:  o.logBodies,
+
+		RetryPolicy: o.retryPolicy,
+		TaskWaiter:  o.taskWaiter,
+
+		ValidateRRSets:    o.validateRRSets,
+		ValidateGeoGroups: o.validateGeo,
+		ValidateIPGroups:  o.validateIPs,
+		ValidateProbes:    o.validateProbes,
+	}
+	t.TokenClient = &http.Client{
+		Transport: &tokenLoggingTransport{client: c, base: base},
+		Timeout:   hc.Timeout,
+	}
+	c.initServices()
+	return c, nil
+}
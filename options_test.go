@@ -0,0 +1,166 @@
+package udnssdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// newAuthServer serves the token endpoint and an accounts index requiring the issued bearer token
+func newAuthServer(t *testing.T, check func(r *http.Request)) (*httptest.Server, *int) {
+	tokens := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/authorization/token":
+			tokens++
+			if check != nil {
+				check(r)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintln(w, `{"access_token":"issued","token_type":"Bearer","expires_in":3600}`)
+		case "/v1/accounts":
+			if check != nil {
+				check(r)
+			}
+			auth := r.Header.Get("Authorization")
+			if auth != "Bearer issued" && auth != "Bearer seeded" {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprintln(w, `{"errorCode":60001,"errorMessage":"invalid_grant"}`)
+				return
+			}
+			mess, _ := json.Marshal(AccountListDTO{Accounts: []Account{Account{AccountName: "terraform"}}})
+			fmt.Fprintln(w, string(mess))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return ts, &tokens
+}
+
+func Test_NewClientWithOptions_Credentials(t *testing.T) {
+	ts, tokens := newAuthServer(t, func(r *http.Request) {
+		if r.URL.Path != "/v1/authorization/token" {
+			return
+		}
+		r.ParseForm()
+		if u := r.PostForm.Get("username"); u != "samdoe" {
+			t.Errorf("username: %q, want: %q", u, "samdoe")
+		}
+		id, _, ok := r.BasicAuth()
+		if !ok {
+			id = r.PostForm.Get("client_id")
+		}
+		if id != "app" {
+			t.Errorf("client_id: %q, want: %q", id, "app")
+		}
+		if s := r.PostForm.Get("scope"); s != "a b" {
+			t.Errorf("scope: %q, want: %q", s, "a b")
+		}
+	})
+	defer ts.Close()
+
+	c, err := NewClientWithOptions(
+		WithBaseURL(ts.URL),
+		WithCredentials("samdoe", "s3cr3t"),
+		WithClientCredentials("app", "shh"),
+		WithScopes("a", "b"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := c.Accounts.Select(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if *tokens != 1 {
+		t.Errorf("token requests: %d, want: %d", *tokens, 1)
+	}
+}
+
+func Test_NewClientWithOptions_Token(t *testing.T) {
+	ts, tokens := newAuthServer(t, nil)
+	defer ts.Close()
+
+	c, err := NewClientWithOptions(
+		WithBaseURL(ts.URL),
+		WithToken(&oauth2.Token{AccessToken: "seeded", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := c.Accounts.Select(); err != nil {
+		t.Fatal(err)
+	}
+	if *tokens != 0 {
+		t.Errorf("token requests: %d, want: %d", *tokens, 0)
+	}
+}
+
+type countingTransport struct {
+	n int
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.n++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func Test_NewClientWithOptions_Transport(t *testing.T) {
+	ts, _ := newAuthServer(t, func(r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); r.URL.Path == "/v1/accounts" && ua != userAgent+" deployer/2.0" {
+			t.Errorf("User-Agent: %q, want: %q", ua, userAgent+" deployer/2.0")
+		}
+	})
+	defer ts.Close()
+
+	rt := &countingTransport{}
+	c, err := NewClientWithOptions(
+		WithBaseURL(ts.URL),
+		WithCredentials("samdoe", "s3cr3t"),
+		WithHTTPClient(&http.Client{Timeout: time.Minute}),
+		WithTransport(rt),
+		WithTimeout(time.Second),
+		WithUserAgent("deployer/2.0"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := c.Accounts.Select(); err != nil {
+		t.Fatal(err)
+	}
+	if rt.n != 2 {
+		t.Errorf("round trips: %d, want: %d", rt.n, 2)
+	}
+	if c.HTTPClient.Timeout != time.Second {
+		t.Errorf("Timeout: %v, want: %v", c.HTTPClient.Timeout, time.Second)
+	}
+}
+
+func Test_NewClientWithOptions_Defaults(t *testing.T) {
+	c, err := NewClientWithOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.BaseURL.String() != DefaultLiveBaseURL {
+		t.Errorf("BaseURL: %v, want: %v", c.BaseURL, DefaultLiveBaseURL)
+	}
+	if c.RetryPolicy != DefaultRetryPolicy() {
+		t.Errorf("RetryPolicy: %+v, want: %+v", c.RetryPolicy, DefaultRetryPolicy())
+	}
+	if c.TaskWaiter != DefaultTaskWaiter() {
+		t.Errorf("TaskWaiter: %+v, want: %+v", c.TaskWaiter, DefaultTaskWaiter())
+	}
+	if c.RRSets == nil || c.Tasks == nil {
+		t.Errorf("services not initialized: %+v", c)
+	}
+}
@@ -0,0 +1,90 @@
+package udnssdk
+
+import (
+	"context"
+	"net/http"
+)
+
+// PageFunc requests the page of results starting at offset, sorted and sized according to q
+type PageFunc[T any] func(ctx context.Context, q QueryInfo, offset int) ([]T, ResultInfo, *http.Response, error)
+
+// Pager streams the results of a paginated list, requesting each page only once the previous one has been consumed.
+//
+//	p := client.RRSets.Pager(RRSetKey{Zone: "example.com."}, Query{Kind: RecordsKind})
+//	for rr, err := range p.All(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(rr.OwnerName, p.TotalCount())
+//	}
+type Pager[T any] struct {
+	// Query sets the Sort, Reverse and Limit, the page size, of the requests; zero values use the API's defaults
+	Query QueryInfo
+
+	client *Client
+	fetch  PageFunc[T]
+	ri     ResultInfo
+	res    *http.Response
+}
+
+// NewPager returns a Pager requesting pages with fetch; c, if not nil, logs the ResultInfo of each page
+func NewPager[T any](c *Client, fetch PageFunc[T]) *Pager[T] {
+	return &Pager[T]{client: c, fetch: fetch}
+}
+
+// All returns an iterator over the results, for use with range.
+// It stops after yielding the first error, with the zero T; breaking out of the loop stops the requests.
+func (p *Pager[T]) All(ctx context.Context) func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		offset := 0
+		for {
+			items, ri, res, err := p.fetch(ctx, p.Query, offset)
+			p.res = res
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			p.ri = ri
+			if p.client != nil {
+				p.client.logResultInfo(ri)
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if ri.ReturnedCount == 0 || ri.ReturnedCount+ri.Offset >= ri.TotalCount {
+				return
+			}
+			offset = ri.ReturnedCount + ri.Offset
+		}
+	}
+}
+
+// Collect returns all the results, along with those collected before an error
+func (p *Pager[T]) Collect(ctx context.Context) ([]T, error) {
+	items := []T{}
+	for item, err := range p.All(ctx) {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// TotalCount returns the total number of results reported by the last page, or 0 before the first
+func (p *Pager[T]) TotalCount() int {
+	return p.ri.TotalCount
+}
+
+// ResultInfo returns the list metadata of the last page
+func (p *Pager[T]) ResultInfo() ResultInfo {
+	return p.ri
+}
+
+// Response returns the response of the last request
+func (p *Pager[T]) Response() *http.Response {
+	return p.res
+}
@@ -0,0 +1,66 @@
+package udnssdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func Test_Pager_RRSets(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("offset") == "4" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintln(w, `[{"errorCode":99999,"errorMessage":"boom"}]`)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		resp := RRSetListDTO{Resultinfo: ResultInfo{TotalCount: 5, Offset: offset, ReturnedCount: 2}}
+		for i := offset; i < offset+2; i++ {
+			resp.Rrsets = append(resp.Rrsets, RRSet{OwnerName: fmt.Sprintf("host%d.example.com.", i), RRType: "A (1)"})
+		}
+		mess, _ := json.Marshal(resp)
+		fmt.Fprintln(w, string(mess))
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	testClient.RetryPolicy = RetryPolicy{MaxAttempts: 1}
+	ctx := context.Background()
+
+	p := testClient.RRSets.Pager(RRSetKey{Zone: "example.com."}, Query{})
+	p.Query = QueryInfo{Sort: "TTL", Reverse: true, Limit: 2}
+	if p.TotalCount() != 0 {
+		t.Errorf("TotalCount before the first page: %d", p.TotalCount())
+	}
+	n := 0
+	for rr, err := range p.All(ctx) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rr.OwnerName != fmt.Sprintf("host%d.example.com.", n) {
+			t.Errorf("item %d: %s", n, rr.OwnerName)
+		}
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	if p.TotalCount() != 5 {
+		t.Errorf("TotalCount: %d", p.TotalCount())
+	}
+	want := []string{"limit=2&offset=0&reverse=true&sort=TTL", "limit=2&offset=2&reverse=true&sort=TTL"}
+	if fmt.Sprint(queries) != fmt.Sprint(want) {
+		t.Errorf("queries: %q, want: %q", queries, want)
+	}
+
+	rrsets, err := testClient.RRSets.Pager(RRSetKey{Zone: "example.com."}, Query{}).Collect(ctx)
+	if err == nil || len(rrsets) != 4 {
+		t.Errorf("Collect: %d RRSets, %v", len(rrsets), err)
+	}
+}
@@ -3,9 +3,10 @@
 package password
 
 import (
+	"context"
 	"net/http"
+	"sync"
 
-	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
 )
 
@@ -36,11 +37,18 @@ type Config struct {
 }
 
 // Client returns an HTTP client using the provided token.
-// The token will auto-refresh as necessary. The underlying
-// HTTP transport will be obtained using the provided context.
+// The token will auto-refresh as necessary. Token requests are made with
+// the context of the request that needed the token, so cancellation and
+// deadlines apply to the token fetch as well. If ctx carries an
+// oauth2.HTTPClient, it is used for both token and resource requests.
 // The returned client and its Transport should not be modified.
 func (c *Config) Client(ctx context.Context) *http.Client {
-	return oauth2.NewClient(ctx, c.TokenSource(ctx))
+	t := &Transport{Config: c}
+	if hc, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && hc != nil {
+		t.Base = hc.Transport
+		t.TokenClient = hc
+	}
+	return &http.Client{Transport: t}
 }
 
 // TokenSource returns a TokenSource that returns t until t expires,
@@ -56,6 +64,18 @@ func (c *Config) TokenSource(ctx context.Context) oauth2.TokenSource {
 	return oauth2.ReuseTokenSource(nil, source)
 }
 
+// Token requests a new token using the password credentials flow.
+// The context governs the token request, including cancellation.
+func (c *Config) Token(ctx context.Context) (*oauth2.Token, error) {
+	config := oauth2.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		Endpoint:     c.Endpoint,
+		Scopes:       c.Scopes,
+	}
+	return config.PasswordCredentialsToken(ctx, c.Username, c.Password)
+}
+
 type tokenSource struct {
 	ctx  context.Context
 	conf *Config
@@ -64,11 +84,69 @@ type tokenSource struct {
 // Token refreshes the token by using a new password credentials request.
 // tokens received this way do not include a refresh token
 func (c *tokenSource) Token() (*oauth2.Token, error) {
-	config := oauth2.Config{
-		ClientID:     c.conf.ClientID,
-		ClientSecret: c.conf.ClientSecret,
-		Endpoint:     c.conf.Endpoint,
-		Scopes:       c.conf.Scopes,
+	return c.conf.Token(c.ctx)
+}
+
+// Transport is an http.RoundTripper that authorizes each request with a
+// password credentials token. Tokens are cached until they expire and are
+// fetched using the context of the request being sent.
+type Transport struct {
+	// Config supplies the credentials and token endpoint.
+	Config *Config
+
+	// Base is the underlying RoundTripper. If nil, http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// TokenClient, if set, is used to send token requests.
+	TokenClient *http.Client
+
+	mu  sync.Mutex
+	tok *oauth2.Token
+}
+
+// RoundTrip authorizes and sends the request.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tok, err := t.TokenContext(req.Context())
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	r := req.Clone(req.Context())
+	tok.SetAuthHeader(r)
+	return t.base().RoundTrip(r)
+}
+
+// TokenContext returns the cached token, or fetches a new one with ctx if
+// there is none or it has expired.
+func (t *Transport) TokenContext(ctx context.Context) (*oauth2.Token, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.tok.Valid() {
+		return t.tok, nil
+	}
+	if t.TokenClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, t.TokenClient)
+	}
+	tok, err := t.Config.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t.tok = tok
+	return tok, nil
+}
+
+// SetToken replaces the cached token.
+func (t *Transport) SetToken(tok *oauth2.Token) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tok = tok
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
 	}
-	return config.PasswordCredentialsToken(c.ctx, c.conf.Username, c.conf.Password)
+	return http.DefaultTransport
 }
@@ -0,0 +1,272 @@
+package udnssdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Here lie the operations of a JSON Patch
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+	PatchMove    = "move"
+	PatchCopy    = "copy"
+	PatchTest    = "test"
+)
+
+// PatchOperation is a single operation of an RFC 6902 JSON Patch
+type PatchOperation struct {
+	Op   string
+	Path string
+	// From is the source path of move and copy operations
+	From string
+	// Value is the value of add, replace and test operations
+	Value interface{}
+}
+
+// MarshalJSON encodes the operation with only the members its op takes,
+// so that zero values such as a TTL of 0 are still sent
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{"op": o.Op, "path": o.Path}
+	switch o.Op {
+	case PatchMove, PatchCopy:
+		m["from"] = o.From
+	case PatchRemove:
+	default:
+		m["value"] = o.Value
+	}
+	return json.Marshal(m)
+}
+
+// JSONPatch is an RFC 6902 JSON Patch document
+type JSONPatch []PatchOperation
+
+// ContentType is the media type a JSONPatch is sent as
+func (JSONPatch) ContentType() string {
+	return "application/json-patch+json"
+}
+
+// RRSetPatch is an UltraDNS merge-style partial update of an RRSet:
+// the fields that are set replace those of the RRSet, the others are left alone
+type RRSetPatch struct {
+	TTL     *int     `json:"ttl,omitempty"`
+	RData   []string `json:"rdata,omitempty"`
+	Profile Profile  `json:"profile,omitempty"`
+}
+
+// JSONPointer joins tokens into an RFC 6901 JSON Pointer, escaping "~" and "/"
+func JSONPointer(tokens ...string) string {
+	r := strings.NewReplacer("~", "~0", "/", "~1")
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteString("/")
+		b.WriteString(r.Replace(t))
+	}
+	return b.String()
+}
+
+// Patch applies the JSON Patch p to the RRSet of k
+func (s *RRSetsService) Patch(k RRSetKey, p JSONPatch) (*http.Response, error) {
+	return s.PatchContext(context.Background(), k, p)
+}
+
+// PatchContext is Patch with a context.Context
+func (s *RRSetsService) PatchContext(ctx context.Context, k RRSetKey, p JSONPatch) (*http.Response, error) {
+	var ignored interface{}
+	return s.client.patch(ctx, k.URI(), p, &ignored)
+}
+
+// MergePatch updates the fields set in p of the RRSet of k
+func (s *RRSetsService) MergePatch(k RRSetKey, p RRSetPatch) (*http.Response, error) {
+	return s.MergePatchContext(context.Background(), k, p)
+}
+
+// MergePatchContext is MergePatch with a context.Context
+func (s *RRSetsService) MergePatchContext(ctx context.Context, k RRSetKey, p RRSetPatch) (*http.Response, error) {
+	var ignored interface{}
+	return s.client.patch(ctx, k.URI(), p, &ignored)
+}
+
+// AddRData appends records to the RRSet of k. In a pool whose profile has rdataInfo, each record
+// gets a copy of the rdataInfo of the last record, with the next priority if it has one; records of
+// directional pools, whose rdataInfo cannot be guessed, are added with AddPoolRData.
+// The RRSet is read to find its profile.
+func (s *RRSetsService) AddRData(k RRSetKey, rdata ...string) (*http.Response, error) {
+	return s.AddRDataContext(context.Background(), k, rdata...)
+}
+
+// AddRDataContext is AddRData with a context.Context
+func (s *RRSetsService) AddRDataContext(ctx context.Context, k RRSetKey, rdata ...string) (*http.Response, error) {
+	rr, err := s.selectOne(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	p, err := addRDataPatch(rr, rdata)
+	if err != nil {
+		return nil, err
+	}
+	return s.PatchContext(ctx, k, p)
+}
+
+// AddPoolRData appends a record with its rdataInfo to the pool of k
+func (s *RRSetsService) AddPoolRData(k RRSetKey, rdata string, info interface{}) (*http.Response, error) {
+	return s.AddPoolRDataContext(context.Background(), k, rdata, info)
+}
+
+// AddPoolRDataContext is AddPoolRData with a context.Context
+func (s *RRSetsService) AddPoolRDataContext(ctx context.Context, k RRSetKey, rdata string, info interface{}) (*http.Response, error) {
+	return s.PatchContext(ctx, k, JSONPatch{
+		{Op: PatchAdd, Path: "/rdata/-", Value: rdata},
+		{Op: PatchAdd, Path: "/profile/rdataInfo/-", Value: info},
+	})
+}
+
+// addRDataPatch returns the JSON Patch appending rdata to rr, and their rdataInfo if rr is a pool
+func addRDataPatch(rr RRSet, rdata []string) (JSONPatch, error) {
+	rp, err := ToRawProfile(rr.Profile)
+	if err != nil {
+		return nil, err
+	}
+	infos, pool := rp["rdataInfo"].([]interface{})
+	if pool {
+		if rp.Schema() == DirPoolSchema {
+			return nil, fmt.Errorf("%s %s is a directional pool; add its records with AddPoolRData", rr.RRType, rr.OwnerName)
+		}
+		if len(infos) == 0 {
+			return nil, fmt.Errorf("%s %s has no rdataInfo to copy", rr.RRType, rr.OwnerName)
+		}
+	}
+
+	var last map[string]interface{}
+	priority := 0.0
+	if pool {
+		last, _ = infos[len(infos)-1].(map[string]interface{})
+		for _, i := range infos {
+			if m, ok := i.(map[string]interface{}); ok {
+				if p, ok := m["priority"].(float64); ok && p > priority {
+					priority = p
+				}
+			}
+		}
+	}
+
+	p := JSONPatch{}
+	for _, r := range rdata {
+		p = append(p, PatchOperation{Op: PatchAdd, Path: "/rdata/-", Value: r})
+		if !pool {
+			continue
+		}
+		info := map[string]interface{}{}
+		for k, v := range last {
+			info[k] = v
+		}
+		if _, ok := info["priority"]; ok {
+			priority++
+			info["priority"] = priority
+		}
+		p = append(p, PatchOperation{Op: PatchAdd, Path: "/profile/rdataInfo/-", Value: info})
+	}
+	return p, nil
+}
+
+// RemoveRData removes records from the RRSet of k, along with their rdataInfo in a pool profile.
+// The RRSet is read to find the records, and the patch tests each of them is still in place,
+// so a concurrent change makes the patch fail instead of removing the wrong record.
+func (s *RRSetsService) RemoveRData(k RRSetKey, rdata ...string) (*http.Response, error) {
+	return s.RemoveRDataContext(context.Background(), k, rdata...)
+}
+
+// RemoveRDataContext is RemoveRData with a context.Context
+func (s *RRSetsService) RemoveRDataContext(ctx context.Context, k RRSetKey, rdata ...string) (*http.Response, error) {
+	rr, err := s.selectOne(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+	p, err := removeRDataPatch(rr, rdata)
+	if err != nil {
+		return nil, err
+	}
+	return s.PatchContext(ctx, k, p)
+}
+
+// selectOne reads the RRSet of k
+func (s *RRSetsService) selectOne(ctx context.Context, k RRSetKey) (RRSet, error) {
+	rrsets, err := s.SelectContext(ctx, k)
+	if err != nil {
+		return RRSet{}, err
+	}
+	if len(rrsets) != 1 {
+		return RRSet{}, fmt.Errorf("%d RRSets found for %s %s", len(rrsets), k.Type, k.Name)
+	}
+	return rrsets[0], nil
+}
+
+// removeRDataPatch returns the JSON Patch removing rdata from rr.
+// Records are removed from the last, so earlier indexes stay valid.
+func removeRDataPatch(rr RRSet, rdata []string) (JSONPatch, error) {
+	remove := map[string]bool{}
+	for _, r := range rdata {
+		remove[r] = true
+	}
+	rp, err := ToRawProfile(rr.Profile)
+	if err != nil {
+		return nil, err
+	}
+	_, infos := rp["rdataInfo"].([]interface{})
+
+	p := JSONPatch{}
+	found := 0
+	for i := len(rr.RData) - 1; i >= 0; i-- {
+		r := rr.RData[i]
+		if !remove[r] {
+			continue
+		}
+		found++
+		path := JSONPointer("rdata", fmt.Sprint(i))
+		p = append(p,
+			PatchOperation{Op: PatchTest, Path: path, Value: r},
+			PatchOperation{Op: PatchRemove, Path: path})
+		if infos {
+			p = append(p, PatchOperation{Op: PatchRemove, Path: JSONPointer("profile", "rdataInfo", fmt.Sprint(i))})
+		}
+	}
+	if found != len(remove) {
+		return nil, fmt.Errorf("%d of %d rdata not found in %s %s", len(remove)-found, len(remove), rr.RRType, rr.OwnerName)
+	}
+	return p, nil
+}
+
+// SetTTL sets the TTL of the RRSet of k
+func (s *RRSetsService) SetTTL(k RRSetKey, ttl int) (*http.Response, error) {
+	return s.SetTTLContext(context.Background(), k, ttl)
+}
+
+// SetTTLContext is SetTTL with a context.Context
+func (s *RRSetsService) SetTTLContext(ctx context.Context, k RRSetKey, ttl int) (*http.Response, error) {
+	return s.PatchContext(ctx, k, JSONPatch{{Op: PatchReplace, Path: "/ttl", Value: ttl}})
+}
+
+// SetProfileField sets a field of the profile of the RRSet of k.
+// path is a JSON Pointer relative to the profile, e.g. "/rdataInfo/2/state" or JSONPointer("order").
+func (s *RRSetsService) SetProfileField(k RRSetKey, path string, value interface{}) (*http.Response, error) {
+	return s.SetProfileFieldContext(context.Background(), k, path, value)
+}
+
+// SetProfileFieldContext is SetProfileField with a context.Context
+func (s *RRSetsService) SetProfileFieldContext(ctx context.Context, k RRSetKey, path string, value interface{}) (*http.Response, error) {
+	return s.PatchContext(ctx, k, JSONPatch{{Op: PatchReplace, Path: "/profile" + path, Value: value}})
+}
+
+// RemoveProfileField removes a field of the profile of the RRSet of k; path is as for SetProfileField
+func (s *RRSetsService) RemoveProfileField(k RRSetKey, path string) (*http.Response, error) {
+	return s.RemoveProfileFieldContext(context.Background(), k, path)
+}
+
+// RemoveProfileFieldContext is RemoveProfileField with a context.Context
+func (s *RRSetsService) RemoveProfileFieldContext(ctx context.Context, k RRSetKey, path string) (*http.Response, error) {
+	return s.PatchContext(ctx, k, JSONPatch{{Op: PatchRemove, Path: "/profile" + path}})
+}
@@ -0,0 +1,100 @@
+package udnssdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_JSONPatch_Marshal(t *testing.T) {
+	p := JSONPatch{
+		{Op: PatchReplace, Path: "/ttl", Value: 0},
+		{Op: PatchRemove, Path: "/rdata/1"},
+		{Op: PatchCopy, From: "/rdata/0", Path: "/rdata/-"},
+		{Op: PatchAdd, Path: JSONPointer("profile", "a/b~c"), Value: false},
+	}
+	got, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"op":"replace","path":"/ttl","value":0},{"op":"remove","path":"/rdata/1"},{"from":"/rdata/0","op":"copy","path":"/rdata/-"},{"op":"add","path":"/profile/a~1b~0c","value":false}]`
+	if string(got) != want {
+		t.Errorf("Marshal: %s\nwant: %s", got, want)
+	}
+}
+
+func Test_RRSets_Patch(t *testing.T) {
+	current := RRSet{
+		OwnerName: "www.example.com.",
+		RRType:    "A (1)",
+		TTL:       300,
+		RData:     []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"},
+		Profile: RawProfile{
+			"@context":  string(SBPoolSchema),
+			"rdataInfo": []interface{}{map[string]interface{}{"state": "NORMAL", "priority": 1}, map[string]interface{}{"state": "NORMAL", "priority": 3}, map[string]interface{}{"state": "NORMAL", "priority": 2}},
+		},
+	}
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			resp := RRSetListDTO{Rrsets: []RRSet{current}, Resultinfo: ResultInfo{TotalCount: 1, ReturnedCount: 1}}
+			mess, _ := json.Marshal(resp)
+			fmt.Fprintln(w, string(mess))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, fmt.Sprintf("%s %s %s %s", r.Method, r.URL.Path, r.Header.Get("Content-Type"), body))
+		fmt.Fprintln(w, `{"message":"Successful"}`)
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	k := RRSetKey{Zone: "example.com.", Type: "A", Name: "www.example.com."}
+	uri := "/v1/zones/example.com./rrsets/A/www.example.com."
+	ttl := 60
+
+	cases := []struct {
+		call func() (*http.Response, error)
+		want string
+	}{
+		{func() (*http.Response, error) { return testClient.RRSets.AddRData(k, "192.0.2.4", "192.0.2.5") },
+			`PATCH ` + uri + ` application/json-patch+json [{"op":"add","path":"/rdata/-","value":"192.0.2.4"},{"op":"add","path":"/profile/rdataInfo/-","value":{"priority":4,"runProbes":false,"state":"NORMAL","threshold":0,"weight":0}},` +
+				`{"op":"add","path":"/rdata/-","value":"192.0.2.5"},{"op":"add","path":"/profile/rdataInfo/-","value":{"priority":5,"runProbes":false,"state":"NORMAL","threshold":0,"weight":0}}]`},
+		{func() (*http.Response, error) {
+			return testClient.RRSets.AddPoolRData(k, "192.0.2.6", map[string]interface{}{"state": "INACTIVE"})
+		},
+			`PATCH ` + uri + ` application/json-patch+json [{"op":"add","path":"/rdata/-","value":"192.0.2.6"},{"op":"add","path":"/profile/rdataInfo/-","value":{"state":"INACTIVE"}}]`},
+		{func() (*http.Response, error) { return testClient.RRSets.RemoveRData(k, "192.0.2.1", "192.0.2.3") },
+			`PATCH ` + uri + ` application/json-patch+json [{"op":"test","path":"/rdata/2","value":"192.0.2.3"},{"op":"remove","path":"/rdata/2"},{"op":"remove","path":"/profile/rdataInfo/2"},` +
+				`{"op":"test","path":"/rdata/0","value":"192.0.2.1"},{"op":"remove","path":"/rdata/0"},{"op":"remove","path":"/profile/rdataInfo/0"}]`},
+		{func() (*http.Response, error) { return testClient.RRSets.SetTTL(k, 0) },
+			`PATCH ` + uri + ` application/json-patch+json [{"op":"replace","path":"/ttl","value":0}]`},
+		{func() (*http.Response, error) {
+			return testClient.RRSets.SetProfileField(k, "/rdataInfo/1/state", "INACTIVE")
+		},
+			`PATCH ` + uri + ` application/json-patch+json [{"op":"replace","path":"/profile/rdataInfo/1/state","value":"INACTIVE"}]`},
+		{func() (*http.Response, error) { return testClient.RRSets.RemoveProfileField(k, "/description") },
+			`PATCH ` + uri + ` application/json-patch+json [{"op":"remove","path":"/profile/description"}]`},
+		{func() (*http.Response, error) { return testClient.RRSets.MergePatch(k, RRSetPatch{TTL: &ttl}) },
+			`PATCH ` + uri + ` application/json {"ttl":60}`},
+	}
+	for i, c := range cases {
+		requests = nil
+		if _, err := c.call(); err != nil {
+			t.Errorf("case %d: %v", i, err)
+			continue
+		}
+		if len(requests) != 1 || requests[0] != c.want+"\n" {
+			t.Errorf("case %d: %q\nwant: %q", i, requests, c.want+"\n")
+		}
+	}
+
+	requests = nil
+	if _, err := testClient.RRSets.RemoveRData(k, "192.0.2.9"); err == nil || len(requests) != 0 {
+		t.Errorf("RemoveRData of a missing record: %v, %q", err, requests)
+	}
+}
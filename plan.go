@@ -0,0 +1,351 @@
+package udnssdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeAction is the operation a Change applies to an RRSet
+type ChangeAction string
+
+// Here lie all ChangeActions
+const (
+	CreateAction ChangeAction = "CREATE"
+	UpdateAction ChangeAction = "UPDATE"
+	DeleteAction ChangeAction = "DELETE"
+)
+
+// Change is a single RRSet operation of a Plan
+type Change struct {
+	Action ChangeAction
+	Key    RRSetKey
+	// Current is the live RRSet; zero for creates
+	Current RRSet
+	// Desired is the RRSet to write; zero for deletes
+	Desired RRSet
+}
+
+// String describes the change in one line
+func (c Change) String() string {
+	switch c.Action {
+	case CreateAction:
+		return fmt.Sprintf("+ %s %s ttl=%d rdata=%q", c.Key.Type, c.Key.Name, c.Desired.TTL, c.Desired.RData)
+	case DeleteAction:
+		return fmt.Sprintf("- %s %s ttl=%d rdata=%q", c.Key.Type, c.Key.Name, c.Current.TTL, c.Current.RData)
+	}
+	diffs := []string{}
+	if c.Current.TTL != c.Desired.TTL {
+		diffs = append(diffs, fmt.Sprintf("ttl=%d->%d", c.Current.TTL, c.Desired.TTL))
+	}
+	if !sameRData(c.Key.Type, c.Current.RData, c.Desired.RData) {
+		diffs = append(diffs, fmt.Sprintf("rdata=%q->%q", c.Current.RData, c.Desired.RData))
+	}
+	if !sameProfile(c.Current.Profile, c.Desired.Profile) {
+		diffs = append(diffs, "profile")
+	}
+	return fmt.Sprintf("~ %s %s %s", c.Key.Type, c.Key.Name, strings.Join(diffs, " "))
+}
+
+// Plan is an ordered list of Changes bringing a zone to a desired state
+type Plan struct {
+	Zone    string
+	Changes []Change
+}
+
+// Empty reports whether the plan has no changes
+func (p Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String describes the plan, one change per line
+func (p Plan) String() string {
+	if p.Empty() {
+		return fmt.Sprintf("zone %s: no changes\n", p.Zone)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "zone %s: %d changes\n", p.Zone, len(p.Changes))
+	for _, c := range p.Changes {
+		fmt.Fprintln(&b, c)
+	}
+	return b.String()
+}
+
+// PlanOptions controls how DiffWithOptions and PlanWithOptions compute a Plan
+type PlanOptions struct {
+	// KeepProfiles keeps the live Profile of the RRSets desired without one, rather than removing it.
+	// PlanZoneFile sets it, since zone files cannot express pools.
+	KeepProfiles bool
+}
+
+// Diff returns the Plan changing the RRSets current of zone into desired.
+// RRSets are matched by owner name, case-insensitively, and record type; rdata is compared
+// regardless of order, and a desired RRSet without a Profile removes the live one.
+// The SOA and apex NS RRSets are managed by UltraDNS and never changed.
+// Updates come first, then creates, then deletes, so records are not removed before their
+// replacements exist. Deletes that would conflict with a created CNAME, or a deleted CNAME
+// with other created records of its owner, are moved to the front of the plan.
+func Diff(zone string, current, desired []RRSet) Plan {
+	return DiffWithOptions(zone, current, desired, PlanOptions{})
+}
+
+// DiffWithOptions is Diff with PlanOptions
+func DiffWithOptions(zone string, current, desired []RRSet, o PlanOptions) Plan {
+	zone = fqdn(strings.ToLower(zone))
+	id := func(rr RRSet) string {
+		return strings.ToLower(absoluteName(rr.OwnerName, zone)) + " " + rrTypeName(rr.RRType)
+	}
+	managed := func(rr RRSet) bool {
+		t := rrTypeName(rr.RRType)
+		apex := strings.EqualFold(absoluteName(rr.OwnerName, zone), zone)
+		return t != "SOA" && !(apex && t == "NS")
+	}
+
+	live := map[string]RRSet{}
+	for _, rr := range current {
+		if managed(rr) {
+			live[id(rr)] = rr
+		}
+	}
+
+	p := Plan{Zone: zone}
+	var creates, updates, deletes []Change
+	wanted := map[string]bool{}
+	for _, rr := range desired {
+		if !managed(rr) {
+			continue
+		}
+		k := id(rr)
+		wanted[k] = true
+		key := RRSetKey{Zone: zone, Type: rrTypeName(rr.RRType), Name: absoluteName(rr.OwnerName, zone)}
+		want := RRSet{OwnerName: key.Name, RRType: key.Type, TTL: rr.TTL, RData: rr.RData, Profile: rr.Profile}
+
+		cur, ok := live[k]
+		if !ok {
+			creates = append(creates, Change{Action: CreateAction, Key: key, Desired: want})
+			continue
+		}
+		if want.Profile == nil && o.KeepProfiles {
+			want.Profile = cur.Profile
+		}
+		if cur.TTL != want.TTL || !sameRData(key.Type, cur.RData, want.RData) || !sameProfile(cur.Profile, want.Profile) {
+			updates = append(updates, Change{Action: UpdateAction, Key: key, Current: cur, Desired: want})
+		}
+	}
+	for k, rr := range live {
+		if !wanted[k] {
+			key := RRSetKey{Zone: zone, Type: rrTypeName(rr.RRType), Name: absoluteName(rr.OwnerName, zone)}
+			deletes = append(deletes, Change{Action: DeleteAction, Key: key, Current: rr})
+		}
+	}
+	sort.Slice(deletes, func(i, j int) bool {
+		if deletes[i].Key.Name != deletes[j].Key.Name {
+			return deletes[i].Key.Name < deletes[j].Key.Name
+		}
+		return deletes[i].Key.Type < deletes[j].Key.Type
+	})
+
+	// owners of created CNAMEs, and of created records other than CNAMEs
+	cnames, others := map[string]bool{}, map[string]bool{}
+	for _, c := range creates {
+		if c.Key.Type == "CNAME" {
+			cnames[strings.ToLower(c.Key.Name)] = true
+		} else {
+			others[strings.ToLower(c.Key.Name)] = true
+		}
+	}
+	var conflicts, rest []Change
+	for _, c := range deletes {
+		owner := strings.ToLower(c.Key.Name)
+		if c.Key.Type == "CNAME" && others[owner] || c.Key.Type != "CNAME" && cnames[owner] {
+			conflicts = append(conflicts, c)
+		} else {
+			rest = append(rest, c)
+		}
+	}
+
+	for _, cs := range [][]Change{conflicts, updates, creates, rest} {
+		p.Changes = append(p.Changes, cs...)
+	}
+	return p
+}
+
+// sameRData reports whether a and b hold the same records in any order. Except for TXT and SPF,
+// whitespace runs and letter case are not significant.
+func sameRData(typ string, a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	norm := func(rs []string) []string {
+		out := make([]string, len(rs))
+		for i, r := range rs {
+			if typ != "TXT" && typ != "SPF" {
+				r = strings.ToLower(strings.Join(strings.Fields(r), " "))
+			}
+			out[i] = r
+		}
+		sort.Strings(out)
+		return out
+	}
+	return reflect.DeepEqual(norm(a), norm(b))
+}
+
+// PlanZoneFile parses the master file r for zone and returns the Plan bringing the live zone to its contents.
+// The live profiles of pools are kept.
+func (s *RRSetsService) PlanZoneFile(r io.Reader, zone string) (Plan, error) {
+	return s.PlanZoneFileContext(context.Background(), r, zone)
+}
+
+// PlanZoneFileContext is PlanZoneFile with a context.Context
+func (s *RRSetsService) PlanZoneFileContext(ctx context.Context, r io.Reader, zone string) (Plan, error) {
+	desired, err := ParseZoneFile(r, zone)
+	if err != nil {
+		return Plan{}, err
+	}
+	return s.PlanWithOptionsContext(ctx, zone, desired, PlanOptions{KeepProfiles: true})
+}
+
+// Plan returns the Plan bringing the live RRSets of zone to desired, as computed by Diff
+func (s *RRSetsService) Plan(zone string, desired []RRSet) (Plan, error) {
+	return s.PlanContext(context.Background(), zone, desired)
+}
+
+// PlanContext is Plan with a context.Context
+func (s *RRSetsService) PlanContext(ctx context.Context, zone string, desired []RRSet) (Plan, error) {
+	return s.PlanWithOptionsContext(ctx, zone, desired, PlanOptions{})
+}
+
+// PlanWithOptions returns the Plan bringing the live RRSets of zone to desired, as computed by DiffWithOptions
+func (s *RRSetsService) PlanWithOptions(zone string, desired []RRSet, o PlanOptions) (Plan, error) {
+	return s.PlanWithOptionsContext(context.Background(), zone, desired, o)
+}
+
+// PlanWithOptionsContext is PlanWithOptions with a context.Context
+func (s *RRSetsService) PlanWithOptionsContext(ctx context.Context, zone string, desired []RRSet, o PlanOptions) (Plan, error) {
+	current, err := s.SelectContext(ctx, RRSetKey{Zone: zone})
+	var er ErrorResponse
+	if errors.As(err, &er) && er.ErrorCode == ErrorCodeDataNotFound {
+		// a zone without records
+		current, err = nil, nil
+	}
+	if err != nil {
+		return Plan{}, err
+	}
+	return DiffWithOptions(zone, current, desired, o), nil
+}
+
+// ApplyOptions controls how ApplyWithOptions performs a Plan
+type ApplyOptions struct {
+	// Progress, if set, is called after each change is attempted, with its
+	// error, if any, and the number of changes attempted so far
+	Progress func(c Change, err error, done, total int)
+	// ContinueOnError keeps applying the remaining changes after a failure
+	ContinueOnError bool
+}
+
+// ChangeError is the failure of a single Change
+type ChangeError struct {
+	Change Change
+	Err    error
+}
+
+func (e *ChangeError) Error() string {
+	return fmt.Sprintf("%s %s %s: %v", e.Change.Action, e.Change.Key.Type, e.Change.Key.Name, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *ChangeError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyError reports a Plan that was only partly applied
+type ApplyError struct {
+	// Applied are the changes that succeeded
+	Applied []Change
+	// Failed are the changes that failed, in plan order
+	Failed []*ChangeError
+	// Skipped are the changes that were not attempted
+	Skipped []Change
+}
+
+func (e *ApplyError) Error() string {
+	msgs := make([]string, len(e.Failed))
+	for i, f := range e.Failed {
+		msgs[i] = f.Error()
+	}
+	total := len(e.Applied) + len(e.Failed) + len(e.Skipped)
+	return fmt.Sprintf("%s (%d of %d changes applied)", strings.Join(msgs, "; "), len(e.Applied), total)
+}
+
+// Unwrap returns the ChangeErrors
+func (e *ApplyError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, f := range e.Failed {
+		errs[i] = f
+	}
+	return errs
+}
+
+// Apply performs the changes of p in order, stopping at the first failure.
+// If a change fails, the returned error is an *ApplyError.
+func (s *RRSetsService) Apply(p Plan) error {
+	return s.ApplyContext(context.Background(), p)
+}
+
+// ApplyContext is Apply with a context.Context
+func (s *RRSetsService) ApplyContext(ctx context.Context, p Plan) error {
+	return s.ApplyWithOptionsContext(ctx, p, ApplyOptions{})
+}
+
+// ApplyWithOptions performs the changes of p in order as controlled by o.
+// If any change fails, or ctx is done, the returned error is an *ApplyError.
+func (s *RRSetsService) ApplyWithOptions(p Plan, o ApplyOptions) error {
+	return s.ApplyWithOptionsContext(context.Background(), p, o)
+}
+
+// ApplyWithOptionsContext is ApplyWithOptions with a context.Context
+func (s *RRSetsService) ApplyWithOptionsContext(ctx context.Context, p Plan, o ApplyOptions) error {
+	res := &ApplyError{}
+	for i, c := range p.Changes {
+		err := ctx.Err()
+		if err == nil {
+			err = s.applyChange(ctx, c)
+		}
+		if err != nil {
+			res.Failed = append(res.Failed, &ChangeError{Change: c, Err: err})
+		} else {
+			res.Applied = append(res.Applied, c)
+		}
+		if o.Progress != nil {
+			o.Progress(c, err, i+1, len(p.Changes))
+		}
+		if err != nil && (!o.ContinueOnError || ctx.Err() != nil) {
+			res.Skipped = append(res.Skipped, p.Changes[i+1:]...)
+			break
+		}
+	}
+	if len(res.Failed) != 0 {
+		return res
+	}
+	return nil
+}
+
+// applyChange performs a single change
+func (s *RRSetsService) applyChange(ctx context.Context, c Change) error {
+	var err error
+	switch c.Action {
+	case CreateAction:
+		_, err = s.CreateContext(ctx, c.Key, c.Desired)
+	case UpdateAction:
+		_, err = s.UpdateContext(ctx, c.Key, c.Desired)
+	case DeleteAction:
+		_, err = s.DeleteContext(ctx, c.Key)
+	default:
+		err = fmt.Errorf("unknown action %q", c.Action)
+	}
+	return err
+}
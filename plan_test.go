@@ -0,0 +1,214 @@
+package udnssdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_Diff(t *testing.T) {
+	current := []RRSet{
+		{OwnerName: "example.com.", RRType: "SOA (6)", TTL: 86400, RData: []string{"pdns1.ultradns.net. admin.example.com. 1 2 3 4 5"}},
+		{OwnerName: "example.com.", RRType: "NS (2)", TTL: 86400, RData: []string{"pdns1.ultradns.net."}},
+		{OwnerName: "same.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"192.0.2.2", "192.0.2.1"}},
+		{OwnerName: "ttl.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"192.0.2.1"}},
+		{OwnerName: "rdata.example.com.", RRType: "MX (15)", TTL: 300, RData: []string{"10 mail.example.com."}},
+		{OwnerName: "cname.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"192.0.2.1"}},
+		{OwnerName: "gone.example.com.", RRType: "TXT (16)", TTL: 300, RData: []string{"bye"}},
+		{OwnerName: "pool.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"192.0.2.1"}, Profile: RawProfile{"@context": string(RDPoolSchema)}},
+	}
+	desired := []RRSet{
+		{OwnerName: "example.com.", RRType: "SOA", TTL: 3600, RData: []string{"ns1.other.net. hostmaster.example.com. 1 2 3 4 5"}},
+		{OwnerName: "example.com.", RRType: "NS", TTL: 3600, RData: []string{"ns1.other.net."}},
+		{OwnerName: "SAME.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1", "192.0.2.2"}},
+		{OwnerName: "ttl", RRType: "A", TTL: 60, RData: []string{"192.0.2.1"}},
+		{OwnerName: "rdata.example.com.", RRType: "MX", TTL: 300, RData: []string{"20  MAIL.example.com."}},
+		{OwnerName: "cname.example.com.", RRType: "CNAME", TTL: 300, RData: []string{"www.example.com."}},
+		{OwnerName: "new.example.com.", RRType: "AAAA", TTL: 300, RData: []string{"2001:db8::1"}},
+		{OwnerName: "pool.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}},
+	}
+
+	p := DiffWithOptions("example.com", current, desired, PlanOptions{KeepProfiles: true})
+
+	want := []string{
+		"DELETE A cname.example.com.",
+		"UPDATE A ttl.example.com.",
+		"UPDATE MX rdata.example.com.",
+		"CREATE CNAME cname.example.com.",
+		"CREATE AAAA new.example.com.",
+		"DELETE TXT gone.example.com.",
+	}
+	if len(p.Changes) != len(want) {
+		t.Fatalf("Diff:\n%s\nwant: %q", p, want)
+	}
+	for i, c := range p.Changes {
+		if got := fmt.Sprintf("%s %s %s", c.Action, c.Key.Type, c.Key.Name); got != want[i] {
+			t.Errorf("Changes[%d]: %s, want: %s", i, got, want[i])
+		}
+		if c.Key.Zone != "example.com." {
+			t.Errorf("Changes[%d].Key.Zone: %q", i, c.Key.Zone)
+		}
+	}
+	if s := p.Changes[1].String(); s != "~ A ttl.example.com. ttl=300->60" {
+		t.Errorf("String: %q", s)
+	}
+
+	p = Diff("example.com.", current[7:], desired[7:])
+	if len(p.Changes) != 1 || p.Changes[0].Action != UpdateAction || p.Changes[0].Desired.Profile != nil {
+		t.Errorf("Diff without a profile:\n%s", p)
+	}
+}
+
+func Test_Diff_Empty(t *testing.T) {
+	p := Diff("example.com.", nil, nil)
+	if !p.Empty() || p.String() != "zone example.com.: no changes\n" {
+		t.Errorf("Diff: %q", p)
+	}
+}
+
+func Test_RRSets_PlanZoneFile_Apply(t *testing.T) {
+	requests := []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			resp := RRSetListDTO{
+				Rrsets: []RRSet{
+					{OwnerName: "old.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"192.0.2.9"}},
+					{OwnerName: "www.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"192.0.2.1"}},
+					{OwnerName: "pool.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"192.0.2.3"}, Profile: RDPoolProfile{Context: RDPoolSchema, Order: OrderRandom}},
+				},
+				Resultinfo: ResultInfo{TotalCount: 3, ReturnedCount: 3},
+			}
+			mess, _ := json.Marshal(resp)
+			fmt.Fprintln(w, string(mess))
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		fmt.Fprintln(w, `{"message":"Successful"}`)
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+
+	zf := "$TTL 300\nwww A 192.0.2.2\nmail MX 10 www\npool A 192.0.2.3\n"
+	p, err := testClient.RRSets.PlanZoneFile(strings.NewReader(zf), "example.com.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testClient.RRSets.Apply(p); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"PUT /v1/zones/example.com./rrsets/A/www.example.com.",
+		"POST /v1/zones/example.com./rrsets/MX/mail.example.com.",
+		"DELETE /v1/zones/example.com./rrsets/A/old.example.com.",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests:\n%s\nwant:\n%s", strings.Join(requests, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func Test_RRSets_Apply_StopsOnError(t *testing.T) {
+	hits := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintln(w, `[{"errorCode":2111,"errorMessage":"Resource Record of type 1 with these attributes already exists in the system."}]`)
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	p := Diff("example.com.", nil, []RRSet{
+		{OwnerName: "a", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}},
+		{OwnerName: "b", RRType: "A", TTL: 300, RData: []string{"192.0.2.2"}},
+	})
+
+	err := testClient.RRSets.Apply(p)
+	if !IsAlreadyExists(err) || !strings.HasPrefix(err.Error(), "CREATE A a.example.com.: ") {
+		t.Errorf("Apply: %v", err)
+	}
+	if hits != 1 {
+		t.Errorf("requests: %d, want: %d", hits, 1)
+	}
+}
+
+func Test_Diff_Updates(t *testing.T) {
+	profile := RawProfile{"@context": string(RDPoolSchema), "order": "FIXED"}
+	current := []RRSet{
+		{OwnerName: "ttl.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"192.0.2.1"}},
+		{OwnerName: "pool.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"192.0.2.1"}, Profile: RawProfile{"@context": string(RDPoolSchema), "order": "ROUND_ROBIN"}},
+		{OwnerName: "web.example.com.", RRType: "CNAME (5)", TTL: 300, RData: []string{"www.example.com."}},
+	}
+	desired := []RRSet{
+		{OwnerName: "ttl", RRType: "A", TTL: 60, RData: []string{"192.0.2.1"}},
+		{OwnerName: "pool", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}, Profile: profile},
+		{OwnerName: "web", RRType: "A", TTL: 300, RData: []string{"192.0.2.5"}},
+	}
+
+	p := Diff("example.com.", current, desired)
+
+	want := []string{
+		"- CNAME web.example.com. ttl=300 rdata=[\"www.example.com.\"]",
+		"~ A ttl.example.com. ttl=300->60",
+		"~ A pool.example.com. profile",
+		"+ A web.example.com. ttl=300 rdata=[\"192.0.2.5\"]",
+	}
+	if len(p.Changes) != len(want) {
+		t.Fatalf("Diff:\n%s\nwant: %q", p, want)
+	}
+	for i, c := range p.Changes {
+		if c.String() != want[i] {
+			t.Errorf("Changes[%d]: %s, want: %s", i, c, want[i])
+		}
+	}
+}
+
+func Test_RRSets_ApplyWithOptions(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/bad.") {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintln(w, `[{"errorCode":56001,"errorMessage":"Cannot find resource record data for the input zone, record type and owner combination."}]`)
+			return
+		}
+		fmt.Fprintln(w, `{"message":"Successful"}`)
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	p := Plan{Zone: "example.com.", Changes: []Change{
+		{Action: DeleteAction, Key: RRSetKey{Zone: "example.com.", Type: "A", Name: "bad.example.com."}},
+		{Action: CreateAction, Key: RRSetKey{Zone: "example.com.", Type: "A", Name: "a.example.com."}, Desired: RRSet{TTL: 300, RData: []string{"192.0.2.1"}}},
+		{Action: DeleteAction, Key: RRSetKey{Zone: "example.com.", Type: "A", Name: "b.example.com."}},
+	}}
+
+	progress := []string{}
+	err := testClient.RRSets.ApplyWithOptions(p, ApplyOptions{
+		ContinueOnError: true,
+		Progress: func(c Change, err error, done, total int) {
+			progress = append(progress, fmt.Sprintf("%d/%d %s %v", done, total, c.Key.Name, err != nil))
+		},
+	})
+	var ae *ApplyError
+	if !errors.As(err, &ae) {
+		t.Fatalf("ApplyWithOptions: %v", err)
+	}
+	if len(ae.Applied) != 2 || len(ae.Failed) != 1 || len(ae.Skipped) != 0 {
+		t.Errorf("ApplyError: %+v", ae)
+	}
+	if !IsNotFound(err) || !strings.HasSuffix(err.Error(), "(2 of 3 changes applied)") {
+		t.Errorf("ApplyWithOptions: %v", err)
+	}
+	want := "1/3 bad.example.com. true,2/3 a.example.com. false,3/3 b.example.com. false"
+	if got := strings.Join(progress, ","); got != want {
+		t.Errorf("progress: %s, want: %s", got, want)
+	}
+
+	err = testClient.RRSets.Apply(p)
+	if !errors.As(err, &ae) || len(ae.Applied) != 0 || len(ae.Skipped) != 2 {
+		t.Errorf("Apply: %v", err)
+	}
+}
@@ -1,9 +1,12 @@
 package udnssdk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"strings"
 )
 
 // ProbeType wraps the possible types of a ProbeInfoDTO
@@ -153,12 +156,12 @@ func (s *ProbeDetailsDTO) MarshalJSON() ([]byte, error) {
 	return json.Marshal(nil)
 }
 
-// GoString returns a string representation of the ProbeDetailsDTO internal data
+// GoString returns a string representation of the ProbeDetailsDTO internal data, with secrets redacted
 func (s *ProbeDetailsDTO) GoString() string {
-	return string(s.data)
+	return redactBody("application/json", s.data)
 }
 func (s *ProbeDetailsDTO) String() string {
-	return string(s.data)
+	return redactBody("application/json", s.data)
 }
 
 // Transaction wraps a transaction response
@@ -193,6 +196,32 @@ type FTPProbeDetailsDTO struct {
 	Limits      map[string]ProbeDetailsLimitDTO `json:"limits"`
 }
 
+// ftpProbeDetailsDTO has the fields but not the methods of FTPProbeDetailsDTO
+type ftpProbeDetailsDTO FTPProbeDetailsDTO
+
+// redacted returns a copy of the details with the Password redacted
+func (d FTPProbeDetailsDTO) redacted() ftpProbeDetailsDTO {
+	if d.Password != "" {
+		d.Password = redacted
+	}
+	return ftpProbeDetailsDTO(d)
+}
+
+// String implements fmt.Stringer, redacting the Password
+func (d FTPProbeDetailsDTO) String() string {
+	return fmt.Sprintf("%+v", d.redacted())
+}
+
+// GoString implements fmt.GoStringer, redacting the Password
+func (d FTPProbeDetailsDTO) GoString() string {
+	return strings.Replace(fmt.Sprintf("%#v", d.redacted()), "ftpProbeDetailsDTO", "FTPProbeDetailsDTO", 1)
+}
+
+// LogValue implements slog.LogValuer, redacting the Password
+func (d FTPProbeDetailsDTO) LogValue() slog.Value {
+	return slog.AnyValue(d.redacted())
+}
+
 // TCPProbeDetailsDTO wraps TCP probe details
 type TCPProbeDetailsDTO struct {
 	Port      int                             `json:"port,omitempty"`
@@ -259,11 +288,31 @@ func (k ProbeKey) URI() string {
 
 // Select returns all probes by a RRSetKey, with an optional query
 func (s *ProbesService) Select(k RRSetKey, query string) ([]ProbeInfoDTO, *http.Response, error) {
+	return s.SelectContext(context.Background(), k, query)
+}
+
+// SelectContext is Select with a context.Context
+func (s *ProbesService) SelectContext(ctx context.Context, k RRSetKey, query string) ([]ProbeInfoDTO, *http.Response, error) {
+	return s.selectURI(ctx, k.ProbesQueryURI(query))
+}
+
+// SelectQuery returns all probes by a RRSetKey matching q
+func (s *ProbesService) SelectQuery(k RRSetKey, q Query) ([]ProbeInfoDTO, *http.Response, error) {
+	return s.SelectQueryContext(context.Background(), k, q)
+}
+
+// SelectQueryContext is SelectQuery with a context.Context
+func (s *ProbesService) SelectQueryContext(ctx context.Context, k RRSetKey, q Query) ([]ProbeInfoDTO, *http.Response, error) {
+	// The probes API does not paginate, so no offset is sent.
+	return s.selectURI(ctx, q.URI(k.ProbesURI(), -1))
+}
+
+// selectURI requests the probes listed at uri
+func (s *ProbesService) selectURI(ctx context.Context, uri string) ([]ProbeInfoDTO, *http.Response, error) {
 	var pld ProbeListDTO
 
 	// This API does not support pagination.
-	uri := k.ProbesQueryURI(query)
-	res, err := s.client.get(uri, &pld)
+	res, err := s.client.get(ctx, uri, &pld)
 
 	ps := []ProbeInfoDTO{}
 	if err == nil {
@@ -276,22 +325,56 @@ func (s *ProbesService) Select(k RRSetKey, query string) ([]ProbeInfoDTO, *http.
 
 // Find returns a probe from a ProbeKey
 func (s *ProbesService) Find(k ProbeKey) (ProbeInfoDTO, *http.Response, error) {
+	return s.FindContext(context.Background(), k)
+}
+
+// FindContext is Find with a context.Context
+func (s *ProbesService) FindContext(ctx context.Context, k ProbeKey) (ProbeInfoDTO, *http.Response, error) {
 	var t ProbeInfoDTO
-	res, err := s.client.get(k.URI(), &t)
+	res, err := s.client.get(ctx, k.URI(), &t)
 	return t, res, err
 }
 
 // Create creates a probe with a RRSetKey using the ProbeInfoDTO dp
 func (s *ProbesService) Create(k RRSetKey, dp ProbeInfoDTO) (*http.Response, error) {
-	return s.client.post(k.ProbesURI(), dp, nil)
+	return s.CreateContext(context.Background(), k, dp)
+}
+
+// CreateContext is Create with a context.Context
+func (s *ProbesService) CreateContext(ctx context.Context, k RRSetKey, dp ProbeInfoDTO) (*http.Response, error) {
+	if err := s.validate(dp); err != nil {
+		return nil, err
+	}
+	return s.client.post(ctx, k.ProbesURI(), dp, nil)
 }
 
 // Update updates a probe given a ProbeKey with the ProbeInfoDTO dp
 func (s *ProbesService) Update(k ProbeKey, dp ProbeInfoDTO) (*http.Response, error) {
-	return s.client.put(k.URI(), dp, nil)
+	return s.UpdateContext(context.Background(), k, dp)
+}
+
+// UpdateContext is Update with a context.Context
+func (s *ProbesService) UpdateContext(ctx context.Context, k ProbeKey, dp ProbeInfoDTO) (*http.Response, error) {
+	if err := s.validate(dp); err != nil {
+		return nil, err
+	}
+	return s.client.put(ctx, k.URI(), dp, nil)
+}
+
+// validate checks a probe with ProbeInfoDTO.Validate if the client validates probes
+func (s *ProbesService) validate(dp ProbeInfoDTO) error {
+	if !s.client.ValidateProbes {
+		return nil
+	}
+	return dp.Validate()
 }
 
 // Delete deletes a probe by its ProbeKey
 func (s *ProbesService) Delete(k ProbeKey) (*http.Response, error) {
-	return s.client.delete(k.URI(), nil)
+	return s.DeleteContext(context.Background(), k)
+}
+
+// DeleteContext is Delete with a context.Context
+func (s *ProbesService) DeleteContext(ctx context.Context, k ProbeKey) (*http.Response, error) {
+	return s.client.delete(ctx, k.URI(), nil)
 }
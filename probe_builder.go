@@ -0,0 +1,313 @@
+package udnssdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ProbeInterval is how often a probe runs
+type ProbeInterval string
+
+// Here lie the intervals a probe can run at
+const (
+	ProbeIntervalHalfMinute     ProbeInterval = "HALF_MINUTE"
+	ProbeIntervalOneMinute      ProbeInterval = "ONE_MINUTE"
+	ProbeIntervalTwoMinutes     ProbeInterval = "TWO_MINUTES"
+	ProbeIntervalFiveMinutes    ProbeInterval = "FIVE_MINUTES"
+	ProbeIntervalTenMinutes     ProbeInterval = "TEN_MINUTES"
+	ProbeIntervalFifteenMinutes ProbeInterval = "FIFTEEN_MINUTES"
+)
+
+// ProbeIntervals returns the valid intervals, shortest first
+func ProbeIntervals() []ProbeInterval {
+	return []ProbeInterval{
+		ProbeIntervalHalfMinute,
+		ProbeIntervalOneMinute,
+		ProbeIntervalTwoMinutes,
+		ProbeIntervalFiveMinutes,
+		ProbeIntervalTenMinutes,
+		ProbeIntervalFifteenMinutes,
+	}
+}
+
+// ProbeAgent is a region probes run from
+type ProbeAgent string
+
+// Here lie the agent regions: the locations of the first probing agents, and the regions that replaced them
+const (
+	ProbeAgentNewYork             ProbeAgent = "NEW_YORK"
+	ProbeAgentPaloAlto            ProbeAgent = "PALO_ALTO"
+	ProbeAgentDallas              ProbeAgent = "DALLAS"
+	ProbeAgentAmsterdam           ProbeAgent = "AMSTERDAM"
+	ProbeAgentAsia                ProbeAgent = "ASIA"
+	ProbeAgentChina               ProbeAgent = "CHINA"
+	ProbeAgentEuropeEast          ProbeAgent = "EUROPE_EAST"
+	ProbeAgentEuropeWest          ProbeAgent = "EUROPE_WEST"
+	ProbeAgentNorthAmericaCentral ProbeAgent = "NORTH_AMERICA_CENTRAL"
+	ProbeAgentNorthAmericaEast    ProbeAgent = "NORTH_AMERICA_EAST"
+	ProbeAgentNorthAmericaWest    ProbeAgent = "NORTH_AMERICA_WEST"
+	ProbeAgentSouthAmerica        ProbeAgent = "SOUTH_AMERICA"
+)
+
+// ProbeAgents returns the valid agent regions
+func ProbeAgents() []ProbeAgent {
+	return []ProbeAgent{
+		ProbeAgentNewYork,
+		ProbeAgentPaloAlto,
+		ProbeAgentDallas,
+		ProbeAgentAmsterdam,
+		ProbeAgentAsia,
+		ProbeAgentChina,
+		ProbeAgentEuropeEast,
+		ProbeAgentEuropeWest,
+		ProbeAgentNorthAmericaCentral,
+		ProbeAgentNorthAmericaEast,
+		ProbeAgentNorthAmericaWest,
+		ProbeAgentSouthAmerica,
+	}
+}
+
+// probeLimitKeys are the numeric limits each type of probe accepts
+var probeLimitKeys = map[ProbeType][]string{
+	DNSProbeType:      {"avgConnect", "avgRun", "connect", "run"},
+	FTPProbeType:      {"avgConnect", "avgRun", "connect", "run"},
+	HTTPProbeType:     {"avgConnect", "avgRun", "connect", "run"},
+	PingProbeType:     {"average", "avgRun", "lossPercent", "run", "total"},
+	SMTPProbeType:     {"avgConnect", "avgRun", "connect", "run"},
+	SMTPSENDProbeType: {"avgConnect", "avgRun", "connect", "run"},
+	TCPProbeType:      {"avgConnect", "connect"},
+}
+
+// ProbeLimitKeys returns the names of the limits a probe of type t accepts, in order
+func ProbeLimitKeys(t ProbeType) []string {
+	return append([]string{}, probeLimitKeys[t]...)
+}
+
+// NewDNSProbe returns a DNS probe run every interval from agents, failing when threshold of them fail
+func NewDNSProbe(interval ProbeInterval, agents []ProbeAgent, threshold int, d DNSProbeDetailsDTO) ProbeInfoDTO {
+	return newProbe(DNSProbeType, interval, agents, threshold, d)
+}
+
+// NewFTPProbe returns an FTP probe run every interval from agents, failing when threshold of them fail
+func NewFTPProbe(interval ProbeInterval, agents []ProbeAgent, threshold int, d FTPProbeDetailsDTO) ProbeInfoDTO {
+	return newProbe(FTPProbeType, interval, agents, threshold, d)
+}
+
+// NewHTTPProbe returns an HTTP probe run every interval from agents, failing when threshold of them fail
+func NewHTTPProbe(interval ProbeInterval, agents []ProbeAgent, threshold int, d HTTPProbeDetailsDTO) ProbeInfoDTO {
+	return newProbe(HTTPProbeType, interval, agents, threshold, d)
+}
+
+// NewPingProbe returns a Ping probe run every interval from agents, failing when threshold of them fail
+func NewPingProbe(interval ProbeInterval, agents []ProbeAgent, threshold int, d PingProbeDetailsDTO) ProbeInfoDTO {
+	return newProbe(PingProbeType, interval, agents, threshold, d)
+}
+
+// NewSMTPProbe returns an SMTP probe run every interval from agents, failing when threshold of them fail
+func NewSMTPProbe(interval ProbeInterval, agents []ProbeAgent, threshold int, d SMTPProbeDetailsDTO) ProbeInfoDTO {
+	return newProbe(SMTPProbeType, interval, agents, threshold, d)
+}
+
+// NewSMTPSENDProbe returns an SMTP SEND probe run every interval from agents, failing when threshold of them fail
+func NewSMTPSENDProbe(interval ProbeInterval, agents []ProbeAgent, threshold int, d SMTPSENDProbeDetailsDTO) ProbeInfoDTO {
+	return newProbe(SMTPSENDProbeType, interval, agents, threshold, d)
+}
+
+// NewTCPProbe returns a TCP probe run every interval from agents, failing when threshold of them fail
+func NewTCPProbe(interval ProbeInterval, agents []ProbeAgent, threshold int, d TCPProbeDetailsDTO) ProbeInfoDTO {
+	return newProbe(TCPProbeType, interval, agents, threshold, d)
+}
+
+func newProbe(t ProbeType, interval ProbeInterval, agents []ProbeAgent, threshold int, d interface{}) ProbeInfoDTO {
+	as := make([]string, 0, len(agents))
+	for _, a := range agents {
+		as = append(as, string(a))
+	}
+	// The details DTOs hold nothing json cannot encode
+	data, _ := json.Marshal(d)
+	return ProbeInfoDTO{
+		ProbeType: t,
+		Interval:  string(interval),
+		Agents:    as,
+		Threshold: threshold,
+		Details:   &ProbeDetailsDTO{data: data, Detail: d, typ: t},
+	}
+}
+
+// ProbeValidationError lists the problems Validate found with a probe.
+// It matches ErrValidation, like the API's own validation errors.
+type ProbeValidationError struct {
+	ProbeType ProbeType
+	Problems  []string
+}
+
+func (e *ProbeValidationError) Error() string {
+	return fmt.Sprintf("invalid %s probe: %s", e.ProbeType, strings.Join(e.Problems, "; "))
+}
+
+// Is reports whether target is ErrValidation
+func (e *ProbeValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Validate checks the type, interval, agents and threshold of the probe, and that its details are of
+// its type, with only the limits that type accepts, each rising from warning to critical to fail
+func (p ProbeInfoDTO) Validate() error {
+	problems := []string{}
+	if _, ok := probeLimitKeys[p.ProbeType]; !ok {
+		problems = append(problems, fmt.Sprintf("unknown probe type %q", p.ProbeType))
+	}
+	if !containsString(probeIntervalStrings(), p.Interval) {
+		problems = append(problems, fmt.Sprintf("interval %q is not one of %s", p.Interval, strings.Join(probeIntervalStrings(), ", ")))
+	}
+	if len(p.Agents) == 0 {
+		problems = append(problems, "at least one agent is required")
+	}
+	seen := map[string]bool{}
+	for _, a := range p.Agents {
+		if !containsString(probeAgentStrings(), a) {
+			problems = append(problems, fmt.Sprintf("unknown agent %q", a))
+		}
+		if seen[a] {
+			problems = append(problems, fmt.Sprintf("agent %q is listed more than once", a))
+		}
+		seen[a] = true
+	}
+	if p.Threshold < 1 || p.Threshold > len(p.Agents) {
+		problems = append(problems, fmt.Sprintf("threshold %d must be between 1 and the %d agents", p.Threshold, len(p.Agents)))
+	}
+	problems = append(problems, p.detailsProblems()...)
+	if len(problems) != 0 {
+		return &ProbeValidationError{ProbeType: p.ProbeType, Problems: problems}
+	}
+	return nil
+}
+
+// detailsProblems checks that the details of p are of its type, and their limits
+func (p ProbeInfoDTO) detailsProblems() []string {
+	if p.Details == nil {
+		return []string{"details are required"}
+	}
+	d := p.Details.Detail
+	if d == nil {
+		if _, ok := probeLimitKeys[p.ProbeType]; !ok {
+			return nil
+		}
+		var err error
+		if d, err = p.Details.GetDetailsObject(p.ProbeType); err != nil {
+			return []string{fmt.Sprintf("details: %v", err)}
+		}
+	}
+
+	if v := reflect.ValueOf(d); v.Kind() == reflect.Ptr && !v.IsNil() {
+		d = v.Elem().Interface()
+	}
+
+	var t ProbeType
+	limits := map[string]map[string]ProbeDetailsLimitDTO{}
+	problems := []string{}
+	switch v := d.(type) {
+	case DNSProbeDetailsDTO:
+		t, limits["limits"] = DNSProbeType, v.Limits
+	case FTPProbeDetailsDTO:
+		t, limits["limits"] = FTPProbeType, v.Limits
+	case HTTPProbeDetailsDTO:
+		t = HTTPProbeType
+		if len(v.Transactions) == 0 {
+			problems = append(problems, "at least one transaction is required")
+		}
+		for i, tx := range v.Transactions {
+			limits[fmt.Sprintf("transaction %d limits", i)] = tx.Limits
+		}
+		if v.TotalLimits != nil {
+			problems = append(problems, probeLimitProblems("totalLimits", "", *v.TotalLimits)...)
+		}
+	case PingProbeDetailsDTO:
+		t, limits["limits"] = PingProbeType, v.Limits
+	case SMTPProbeDetailsDTO:
+		t, limits["limits"] = SMTPProbeType, v.Limits
+	case SMTPSENDProbeDetailsDTO:
+		t, limits["limits"] = SMTPSENDProbeType, v.Limits
+	case TCPProbeDetailsDTO:
+		t, limits["limits"] = TCPProbeType, v.Limits
+	default:
+		return []string{fmt.Sprintf("details of type %T are not probe details", d)}
+	}
+	if t != p.ProbeType {
+		return []string{fmt.Sprintf("details are those of a %s probe", t)}
+	}
+
+	where := make([]string, 0, len(limits))
+	for w := range limits {
+		where = append(where, w)
+	}
+	sort.Strings(where)
+	for _, w := range where {
+		keys := make([]string, 0, len(limits[w]))
+		for k := range limits[w] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if !containsString(probeLimitKeys[t], k) {
+				problems = append(problems, fmt.Sprintf("%s: %s probes have no %q limit; they accept %s", w, t, k, strings.Join(probeLimitKeys[t], ", ")))
+				continue
+			}
+			problems = append(problems, probeLimitProblems(w, k, limits[w][k])...)
+		}
+	}
+	return problems
+}
+
+// probeLimitProblems checks that the values set in l are positive and rise from warning to critical to fail
+func probeLimitProblems(where, key string, l ProbeDetailsLimitDTO) []string {
+	name := where
+	if key != "" {
+		name = fmt.Sprintf("%s %q", where, key)
+	}
+	values := []struct {
+		level string
+		value int
+	}{{"warning", l.Warning}, {"critical", l.Critical}, {"fail", l.Fail}}
+
+	problems := []string{}
+	var last string
+	prev := 0
+	for _, v := range values {
+		switch {
+		case v.value < 0:
+			problems = append(problems, fmt.Sprintf("%s: %s %d is negative", name, v.level, v.value))
+		case v.value == 0:
+		case v.value <= prev:
+			problems = append(problems, fmt.Sprintf("%s: %s %d must be greater than %s %d", name, v.level, v.value, last, prev))
+		default:
+			last, prev = v.level, v.value
+		}
+		if key == "lossPercent" && v.value > 100 {
+			problems = append(problems, fmt.Sprintf("%s: %s %d is over 100 percent", name, v.level, v.value))
+		}
+	}
+	if l.Warning == 0 && l.Critical == 0 && l.Fail == 0 {
+		problems = append(problems, fmt.Sprintf("%s sets no limit", name))
+	}
+	return problems
+}
+
+func probeIntervalStrings() []string {
+	s := []string{}
+	for _, i := range ProbeIntervals() {
+		s = append(s, string(i))
+	}
+	return s
+}
+
+func probeAgentStrings() []string {
+	s := []string{}
+	for _, a := range ProbeAgents() {
+		s = append(s, string(a))
+	}
+	return s
+}
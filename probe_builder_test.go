@@ -0,0 +1,165 @@
+package udnssdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_NewHTTPProbe(t *testing.T) {
+	d := HTTPProbeDetailsDTO{
+		Transactions: []Transaction{{
+			Method: "GET",
+			URL:    "https://example.com/",
+			Limits: map[string]ProbeDetailsLimitDTO{"run": {Warning: 5, Critical: 10, Fail: 15}},
+		}},
+	}
+	p := NewHTTPProbe(ProbeIntervalFiveMinutes, []ProbeAgent{ProbeAgentNewYork, ProbeAgentDallas}, 2, d)
+
+	if p.ProbeType != HTTPProbeType || p.Interval != "FIVE_MINUTES" || !reflect.DeepEqual(p.Agents, []string{"NEW_YORK", "DALLAS"}) || p.Threshold != 2 {
+		t.Errorf("NewHTTPProbe: %+v", p)
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	got, err := p.Details.HTTPProbeDetails()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, d) {
+		t.Errorf("HTTPProbeDetails: %+v, want: %+v", got, d)
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded ProbeInfoDTO
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := decoded.Validate(); err != nil {
+		t.Errorf("Validate decoded: %v", err)
+	}
+}
+
+func Test_ProbeInfoDTO_Validate(t *testing.T) {
+	agents := []ProbeAgent{ProbeAgentNewYork, ProbeAgentDallas}
+	cases := []struct {
+		name  string
+		probe ProbeInfoDTO
+		want  []string
+	}{
+		{
+			name:  "valid ping",
+			probe: NewPingProbe(ProbeIntervalOneMinute, agents, 1, PingProbeDetailsDTO{Limits: map[string]ProbeDetailsLimitDTO{"lossPercent": {Warning: 10, Critical: 20, Fail: 50}}}),
+		},
+		{
+			name:  "fail only",
+			probe: NewTCPProbe(ProbeIntervalOneMinute, agents, 2, TCPProbeDetailsDTO{Port: 443, Limits: map[string]ProbeDetailsLimitDTO{"connect": {Fail: 20}}}),
+		},
+		{
+			name:  "limits out of order",
+			probe: NewTCPProbe(ProbeIntervalOneMinute, agents, 2, TCPProbeDetailsDTO{Limits: map[string]ProbeDetailsLimitDTO{"connect": {Warning: 20, Critical: 10, Fail: 10}}}),
+			want:  []string{`limits "connect": critical 10 must be greater than warning 20`, `limits "connect": fail 10 must be greater than warning 20`},
+		},
+		{
+			name:  "limit of another type",
+			probe: NewTCPProbe(ProbeIntervalOneMinute, agents, 2, TCPProbeDetailsDTO{Limits: map[string]ProbeDetailsLimitDTO{"run": {Fail: 10}}}),
+			want:  []string{`limits: TCP probes have no "run" limit; they accept avgConnect, connect`},
+		},
+		{
+			name:  "loss over 100 percent",
+			probe: NewPingProbe(ProbeIntervalOneMinute, agents, 2, PingProbeDetailsDTO{Limits: map[string]ProbeDetailsLimitDTO{"lossPercent": {Fail: 150}}}),
+			want:  []string{`limits "lossPercent": fail 150 is over 100 percent`},
+		},
+		{
+			name:  "empty limit",
+			probe: NewSMTPProbe(ProbeIntervalOneMinute, agents, 2, SMTPProbeDetailsDTO{Limits: map[string]ProbeDetailsLimitDTO{"connect": {}}}),
+			want:  []string{`limits "connect" sets no limit`},
+		},
+		{
+			name: "http transactions and total limits",
+			probe: NewHTTPProbe(ProbeIntervalOneMinute, agents, 2, HTTPProbeDetailsDTO{
+				Transactions: []Transaction{{Method: "GET", URL: "https://example.com/", Limits: map[string]ProbeDetailsLimitDTO{"lossPercent": {Fail: 5}}}},
+				TotalLimits:  &ProbeDetailsLimitDTO{Warning: -1, Fail: 10},
+			}),
+			want: []string{`totalLimits: warning -1 is negative`, `transaction 0 limits: HTTP probes have no "lossPercent" limit; they accept avgConnect, avgRun, connect, run`},
+		},
+		{
+			name:  "interval, agents and threshold",
+			probe: NewDNSProbe("SIX_MINUTES", []ProbeAgent{ProbeAgentAsia, ProbeAgentAsia, "MARS"}, 4, DNSProbeDetailsDTO{}),
+			want: []string{
+				`interval "SIX_MINUTES" is not one of HALF_MINUTE, ONE_MINUTE, TWO_MINUTES, FIVE_MINUTES, TEN_MINUTES, FIFTEEN_MINUTES`,
+				`agent "ASIA" is listed more than once`,
+				`unknown agent "MARS"`,
+				`threshold 4 must be between 1 and the 3 agents`,
+			},
+		},
+		{
+			name: "details of another type",
+			probe: ProbeInfoDTO{
+				ProbeType: PingProbeType, Interval: "ONE_MINUTE", Agents: []string{"DALLAS"}, Threshold: 1,
+				Details: &ProbeDetailsDTO{Detail: &TCPProbeDetailsDTO{}},
+			},
+			want: []string{"details are those of a TCP probe"},
+		},
+		{
+			name:  "no details",
+			probe: ProbeInfoDTO{ProbeType: PingProbeType, Interval: "ONE_MINUTE", Agents: []string{"DALLAS"}, Threshold: 1},
+			want:  []string{"details are required"},
+		},
+	}
+	for _, c := range cases {
+		err := c.probe.Validate()
+		if c.want == nil {
+			if err != nil {
+				t.Errorf("%s: %v", c.name, err)
+			}
+			continue
+		}
+		if !IsValidation(err) {
+			t.Errorf("%s: %v, want a validation error", c.name, err)
+			continue
+		}
+		if got := err.(*ProbeValidationError).Problems; !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: %q, want: %q", c.name, got, c.want)
+		}
+	}
+}
+
+func Test_ProbesService_Create_Validation(t *testing.T) {
+	hits := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	testClient.ValidateProbes = true
+	k := RRSetKey{Zone: "example.com.", Type: "A", Name: "pool"}
+	invalid := NewPingProbe(ProbeIntervalOneMinute, []ProbeAgent{ProbeAgentDallas}, 1, PingProbeDetailsDTO{Limits: map[string]ProbeDetailsLimitDTO{"connect": {Fail: 10}}})
+
+	if _, err := testClient.Probes.Create(k, invalid); !IsValidation(err) || !strings.Contains(err.Error(), "invalid PING probe") {
+		t.Errorf("Create: %v, want a validation error", err)
+	}
+	if _, err := testClient.Probes.Update(ProbeKey{Zone: k.Zone, Name: k.Name, ID: "1"}, invalid); !IsValidation(err) {
+		t.Errorf("Update: %v, want a validation error", err)
+	}
+	valid := NewPingProbe(ProbeIntervalOneMinute, []ProbeAgent{ProbeAgentDallas}, 1, PingProbeDetailsDTO{Limits: map[string]ProbeDetailsLimitDTO{"run": {Fail: 10}}})
+	if _, err := testClient.Probes.Create(k, valid); err != nil {
+		t.Errorf("Create: %v", err)
+	}
+	testClient.ValidateProbes = false
+	if _, err := testClient.Probes.Create(k, invalid); err != nil {
+		t.Errorf("Create without validation: %v", err)
+	}
+	if hits != 2 {
+		t.Errorf("requests: %d, want: %d", hits, 2)
+	}
+}
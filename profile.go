@@ -0,0 +1,887 @@
+package udnssdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// ProfileSchema are the schema URIs for RRSet Profiles
+type ProfileSchema string
+
+const (
+	// DirPoolSchema is the schema URI for a Directional pool profile
+	DirPoolSchema ProfileSchema = "http://schemas.ultradns.com/DirPool.jsonschema"
+	// RDPoolSchema is the schema URI for a Resource Distribution pool profile
+	RDPoolSchema = "http://schemas.ultradns.com/RDPool.jsonschema"
+	// SBPoolSchema is the schema URI for a SiteBacker pool profile
+	SBPoolSchema = "http://schemas.ultradns.com/SBPool.jsonschema"
+	// TCPoolSchema is the schema URI for a Traffic Controller pool profile
+	TCPoolSchema = "http://schemas.ultradns.com/TCPool.jsonschema"
+	// SFPoolSchema is the schema URI for a Simple Failover pool profile
+	SFPoolSchema = "http://schemas.ultradns.com/SFPool.jsonschema"
+	// SLBPoolSchema is the schema URI for a Simple Load Balancing pool profile
+	SLBPoolSchema = "http://schemas.ultradns.com/SLBPool.jsonschema"
+)
+
+// Profile is the pool profile of an RRSet. It is implemented by the profile types of each
+// schema, by UnknownProfile for schemas without one, and by RawProfile.
+type Profile interface {
+	// Schema returns the JSON-LD @context of the profile
+	Schema() ProfileSchema
+}
+
+// profileDecoders decode the JSON of a profile into the type of its schema
+var profileDecoders = map[ProfileSchema]func([]byte) (Profile, error){
+	DirPoolSchema: decodeJSONProfile[DirPoolProfile],
+	RDPoolSchema:  decodeJSONProfile[RDPoolProfile],
+	SBPoolSchema:  decodeJSONProfile[SBPoolProfile],
+	TCPoolSchema:  decodeJSONProfile[TCPoolProfile],
+	SFPoolSchema:  decodeJSONProfile[SFPoolProfile],
+	SLBPoolSchema: decodeJSONProfile[SLBPoolProfile],
+}
+
+func decodeJSONProfile[T Profile](data []byte) (Profile, error) {
+	var p T
+	err := json.Unmarshal(data, &p)
+	return p, err
+}
+
+// ParseProfile decodes the JSON of a profile into the type of its @context,
+// or into an UnknownProfile if its schema has no type. Empty or null JSON is a nil Profile.
+func ParseProfile(data []byte) (Profile, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	var c struct {
+		Context ProfileSchema `json:"@context"`
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	if decode, ok := profileDecoders[c.Context]; ok {
+		p, err := decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s profile: %w", c.Context, err)
+		}
+		return p, nil
+	}
+	return UnknownProfile{Context: c.Context, JSON: append(json.RawMessage(nil), data...)}, nil
+}
+
+// ToRawProfile converts p to a RawProfile holding its JSON encoding
+func ToRawProfile(p Profile) (RawProfile, error) {
+	if p == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	rp := RawProfile{}
+	if err := json.Unmarshal(b, &rp); err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
+// sameProfile reports whether a and b encode to the same JSON, whatever their types
+func sameProfile(a, b Profile) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ra, err := ToRawProfile(a)
+	if err != nil {
+		return false
+	}
+	rb, err := ToRawProfile(b)
+	if err != nil {
+		return false
+	}
+	// maps are encoded with sorted keys, so equal profiles encode to the same bytes
+	ab, _ := json.Marshal(ra)
+	bb, _ := json.Marshal(rb)
+	return bytes.Equal(ab, bb)
+}
+
+// UnknownProfile is a profile of a schema without a type in this package,
+// kept as the JSON it was received as so that it is sent back unchanged
+type UnknownProfile struct {
+	Context ProfileSchema
+	JSON    json.RawMessage
+}
+
+// Schema returns the @context of the profile
+func (p UnknownProfile) Schema() ProfileSchema {
+	return p.Context
+}
+
+// MarshalJSON returns the JSON of the profile as it was received
+func (p UnknownProfile) MarshalJSON() ([]byte, error) {
+	if len(p.JSON) == 0 {
+		return []byte("null"), nil
+	}
+	return p.JSON, nil
+}
+
+// RawProfile represents the naive interface to an RRSet Profile
+type RawProfile map[string]interface{}
+
+// Schema returns the @context of the profile, or "" if it has none
+func (rp RawProfile) Schema() ProfileSchema {
+	switch c := rp["@context"].(type) {
+	case string:
+		return ProfileSchema(c)
+	case ProfileSchema:
+		return c
+	}
+	return ""
+}
+
+// Context extracts the schema context from a RawProfile
+func (rp RawProfile) Context() ProfileSchema {
+	return rp.Schema()
+}
+
+// Profile converts rp to the Profile type of its @context, as ParseProfile does
+func (rp RawProfile) Profile() (Profile, error) {
+	if rp == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(rp)
+	if err != nil {
+		return nil, err
+	}
+	return ParseProfile(b)
+}
+
+// GetProfileObject extracts the full Profile by its schema type
+func (rp RawProfile) GetProfileObject() (interface{}, error) {
+	c := rp.Context()
+	switch c {
+	case DirPoolSchema:
+		return rp.DirPoolProfile()
+	case RDPoolSchema:
+		return rp.RDPoolProfile()
+	case SBPoolSchema:
+		return rp.SBPoolProfile()
+	case TCPoolSchema:
+		return rp.TCPoolProfile()
+	case SFPoolSchema:
+		return rp.SFPoolProfile()
+	case SLBPoolSchema:
+		return rp.SLBPoolProfile()
+	default:
+		return nil, fmt.Errorf("fallthrough on GetProfileObject type %s", c)
+	}
+}
+
+// decodeProfile converts a RawProfile into the profile type rawVal points to through its JSON encoding,
+// as ParseProfile does, so that the members the type has no field for are kept
+func decodeProfile(rp RawProfile, rawVal interface{}) error {
+	b, err := json.Marshal(rp)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, rawVal)
+}
+
+// DirPoolProfile extracts the full Profile as a DirPoolProfile or returns an error
+func (rp RawProfile) DirPoolProfile() (DirPoolProfile, error) {
+	var result DirPoolProfile
+	c := rp.Context()
+	if c != DirPoolSchema {
+		return result, fmt.Errorf("incorrect JSON-LD @context for DirPoolProfile %s", c)
+	}
+	err := decodeProfile(rp, &result)
+	return result, err
+}
+
+// RDPoolProfile extracts the full Profile as a RDPoolProfile or returns an error
+func (rp RawProfile) RDPoolProfile() (RDPoolProfile, error) {
+	var result RDPoolProfile
+	c := rp.Context()
+	if c != RDPoolSchema {
+		return result, fmt.Errorf("incorrect JSON-LD @context for RDPoolProfile %s", c)
+	}
+	err := decodeProfile(rp, &result)
+	return result, err
+}
+
+// SBPoolProfile extracts the full Profile as a SBPoolProfile or returns an error
+func (rp RawProfile) SBPoolProfile() (SBPoolProfile, error) {
+	var result SBPoolProfile
+	c := rp.Context()
+	if c != SBPoolSchema {
+		return result, fmt.Errorf("incorrect JSON-LD @context for SBPoolProfile %s", c)
+	}
+	err := decodeProfile(rp, &result)
+	return result, err
+}
+
+// TCPoolProfile extracts the full Profile as a TCPoolProfile or returns an error
+func (rp RawProfile) TCPoolProfile() (TCPoolProfile, error) {
+	var result TCPoolProfile
+	c := rp.Context()
+	if c != TCPoolSchema {
+		return result, fmt.Errorf("incorrect JSON-LD @context for TCPoolProfile %s", c)
+	}
+	err := decodeProfile(rp, &result)
+	return result, err
+}
+
+// SFPoolProfile extracts the full Profile as a SFPoolProfile or returns an error
+func (rp RawProfile) SFPoolProfile() (SFPoolProfile, error) {
+	var result SFPoolProfile
+	c := rp.Context()
+	if c != SFPoolSchema {
+		return result, fmt.Errorf("incorrect JSON-LD @context for SFPoolProfile %s", c)
+	}
+	err := decodeProfile(rp, &result)
+	return result, err
+}
+
+// SLBPoolProfile extracts the full Profile as a SLBPoolProfile or returns an error
+func (rp RawProfile) SLBPoolProfile() (SLBPoolProfile, error) {
+	var result SLBPoolProfile
+	c := rp.Context()
+	if c != SLBPoolSchema {
+		return result, fmt.Errorf("incorrect JSON-LD @context for SLBPoolProfile %s", c)
+	}
+	err := decodeProfile(rp, &result)
+	return result, err
+}
+
+// encodeProfile converts a profile to a RawProfile through its JSON encoding,
+// so that omitempty and pointers are honored as they are on the wire
+func encodeProfile(p Profile) RawProfile {
+	rp, _ := ToRawProfile(p)
+	return rp
+}
+
+// unknownMembers is embedded in the profile types and the objects they hold to keep the members of the JSON
+// object they were decoded from that they have no field for, so that encoding them sends back what the API returned
+type unknownMembers struct {
+	members string
+}
+
+func (u unknownMembers) unknown() string {
+	return u.members
+}
+
+func (u *unknownMembers) setUnknown(members string) {
+	u.members = members
+}
+
+// plainStruct returns a struct type with the exported fields of the struct type t, and their indices in t.
+// It has none of the methods of t, so that encoding/json encodes and decodes its fields without calling back into them.
+// Struct fields tagged omitempty are pointers in it, so that they are omitted when zero, as RawProfile always did.
+func plainStruct(t reflect.Type) (reflect.Type, []int) {
+	fields := []reflect.StructField{}
+	index := []int{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		pf := reflect.StructField{Name: f.Name, Type: f.Type, Tag: f.Tag}
+		if _, opts, _ := strings.Cut(f.Tag.Get("json"), ","); f.Type.Kind() == reflect.Struct && strings.Contains(opts, "omitempty") {
+			pf.Type = reflect.PointerTo(f.Type)
+		}
+		fields = append(fields, pf)
+		index = append(index, i)
+	}
+	return reflect.StructOf(fields), index
+}
+
+// setField sets dst to src, either of which may be a pointer to the struct the other is, nil when it is zero
+func setField(dst, src reflect.Value) {
+	switch {
+	case dst.Type() == src.Type():
+		dst.Set(src)
+	case dst.Kind() == reflect.Pointer:
+		if !src.IsZero() {
+			dst.Set(reflect.New(src.Type()))
+			dst.Elem().Set(src)
+		}
+	case src.IsNil():
+		dst.SetZero()
+	default:
+		dst.Set(src.Elem())
+	}
+}
+
+// marshalKeepingUnknown encodes v, a struct embedding unknownMembers, adding the members it was decoded with
+// but has no field for. A Profile without a Context is encoded with its Schema as its @context.
+func marshalKeepingUnknown(v interface{ unknown() string }) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	pt, index := plainStruct(rv.Type())
+	pv := reflect.New(pt).Elem()
+	for i, j := range index {
+		setField(pv.Field(i), rv.Field(j))
+	}
+	if p, ok := v.(Profile); ok {
+		if c := pv.FieldByName("Context"); c.IsValid() && c.String() == "" {
+			c.SetString(string(p.Schema()))
+		}
+	}
+
+	b, err := json.Marshal(pv.Interface())
+	if err != nil || v.unknown() == "" {
+		return b, err
+	}
+	var members, extra map[string]json.RawMessage
+	if err := json.Unmarshal(b, &members); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(v.unknown()), &extra); err != nil {
+		return nil, err
+	}
+	for name, m := range extra {
+		if _, ok := members[name]; !ok {
+			members[name] = m
+		}
+	}
+	return json.Marshal(members)
+}
+
+// unmarshalKeepingUnknown decodes the JSON object data into v, a pointer to a struct embedding unknownMembers,
+// keeping the members it has no field for
+func unmarshalKeepingUnknown(data []byte, v interface{ setUnknown(string) }) error {
+	rv := reflect.ValueOf(v).Elem()
+	pt, index := plainStruct(rv.Type())
+	pv := reflect.New(pt).Elem()
+	for i, j := range index {
+		setField(pv.Field(i), rv.Field(j))
+	}
+	if err := json.Unmarshal(data, pv.Addr().Interface()); err != nil {
+		return err
+	}
+	for i, j := range index {
+		setField(rv.Field(j), pv.Field(i))
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	known := jsonFieldNames(rv.Type())
+	for name := range members {
+		// encoding/json matches member names to fields case-insensitively
+		if known[strings.ToLower(name)] {
+			delete(members, name)
+		}
+	}
+	if len(members) == 0 {
+		v.setUnknown("")
+		return nil
+	}
+	b, err := json.Marshal(members)
+	if err != nil {
+		return err
+	}
+	v.setUnknown(string(b))
+	return nil
+}
+
+// jsonFieldNames returns the lower-cased JSON member names of the fields of the struct type t
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+	return names
+}
+
+// RawProfile converts to a naive RawProfile
+func (p DirPoolProfile) RawProfile() RawProfile {
+	return encodeProfile(p)
+}
+
+// RawProfile converts to a naive RawProfile
+func (p RDPoolProfile) RawProfile() RawProfile {
+	return encodeProfile(p)
+}
+
+// RawProfile converts to a naive RawProfile
+func (p SBPoolProfile) RawProfile() RawProfile {
+	return encodeProfile(p)
+}
+
+// RawProfile converts to a naive RawProfile
+func (p TCPoolProfile) RawProfile() RawProfile {
+	return encodeProfile(p)
+}
+
+// RawProfile converts to a naive RawProfile
+func (p SFPoolProfile) RawProfile() RawProfile {
+	return encodeProfile(p)
+}
+
+// RawProfile converts to a naive RawProfile
+func (p SLBPoolProfile) RawProfile() RawProfile {
+	return encodeProfile(p)
+}
+
+// DirPoolProfile wraps a Profile for a Directional Pool
+type DirPoolProfile struct {
+	Context         ProfileSchema `json:"@context"`
+	Description     string        `json:"description"`
+	ConflictResolve string        `json:"conflictResolve,omitempty"`
+	RDataInfo       []DPRDataInfo `json:"rdataInfo"`
+	NoResponse      DPRDataInfo   `json:"noResponse,omitempty"`
+
+	unknownMembers
+}
+
+// Schema returns DirPoolSchema
+func (DirPoolProfile) Schema() ProfileSchema {
+	return DirPoolSchema
+}
+
+// MarshalJSON encodes the profile, with DirPoolSchema as its @context if it has none
+func (p DirPoolProfile) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(p)
+}
+
+// UnmarshalJSON decodes the profile
+func (p *DirPoolProfile) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, p)
+}
+
+// DPRDataInfo wraps the rdataInfo object of a DirPoolProfile response
+type DPRDataInfo struct {
+	AllNonConfigured bool     `json:"allNonConfigured,omitempty" terraform:"all_non_configured"`
+	IPInfo           *IPInfo  `json:"ipInfo,omitempty" terraform:"ip_info"`
+	GeoInfo          *GeoInfo `json:"geoInfo,omitempty" terraform:"geo_info"`
+	Type             string   `json:"type,omitempty" terraform:"type"` // not mentioned in REST API doc
+
+	unknownMembers
+}
+
+// MarshalJSON encodes the DPRDataInfo
+func (v DPRDataInfo) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(v)
+}
+
+// UnmarshalJSON decodes the DPRDataInfo
+func (v *DPRDataInfo) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, v)
+}
+
+// IPInfo wraps the ipInfo object of a DPRDataInfo
+type IPInfo struct {
+	Name           string      `json:"name" terraform:"name"`
+	IsAccountLevel bool        `json:"isAccountLevel,omitempty" terraform:"is_account_level"`
+	Ips            []IPAddrDTO `json:"ips,omitempty" terraform:"-"`
+
+	unknownMembers
+}
+
+// MarshalJSON encodes the IPInfo
+func (v IPInfo) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(v)
+}
+
+// UnmarshalJSON decodes the IPInfo
+func (v *IPInfo) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, v)
+}
+
+// GeoInfo wraps the geoInfo object of a DPRDataInfo
+type GeoInfo struct {
+	Name           string   `json:"name" terraform:"name"`
+	IsAccountLevel bool     `json:"isAccountLevel,omitempty" terraform:"is_account_level"`
+	Codes          []string `json:"codes,omitempty" terraform:"-"`
+
+	unknownMembers
+}
+
+// MarshalJSON encodes the GeoInfo
+func (v GeoInfo) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(v)
+}
+
+// UnmarshalJSON decodes the GeoInfo
+func (v *GeoInfo) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, v)
+}
+
+// Here lie the values of the order of RDPoolProfile and SBPoolProfile: the order records are answered in
+const (
+	OrderFixed      = "FIXED"
+	OrderRandom     = "RANDOM"
+	OrderRoundRobin = "ROUND_ROBIN"
+)
+
+// RDPoolProfile wraps a Profile for a Resource Distribution pool
+type RDPoolProfile struct {
+	Context     ProfileSchema `json:"@context"`
+	Order       string        `json:"order"`
+	Description string        `json:"description"`
+
+	unknownMembers
+}
+
+// Schema returns RDPoolSchema
+func (RDPoolProfile) Schema() ProfileSchema {
+	return RDPoolSchema
+}
+
+// MarshalJSON encodes the profile, with RDPoolSchema as its @context if it has none
+func (p RDPoolProfile) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(p)
+}
+
+// UnmarshalJSON decodes the profile
+func (p *RDPoolProfile) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, p)
+}
+
+// SBPoolProfile wraps a Profile for a SiteBacker pool
+type SBPoolProfile struct {
+	Context       ProfileSchema  `json:"@context"`
+	Description   string         `json:"description"`
+	RunProbes     bool           `json:"runProbes"`
+	ActOnProbes   bool           `json:"actOnProbes"`
+	Order         string         `json:"order,omitempty"`
+	MaxActive     int            `json:"maxActive,omitempty"`
+	MaxServed     int            `json:"maxServed,omitempty"`
+	RDataInfo     []SBRDataInfo  `json:"rdataInfo"`
+	BackupRecords []BackupRecord `json:"backupRecords"`
+
+	unknownMembers
+}
+
+// Schema returns SBPoolSchema
+func (SBPoolProfile) Schema() ProfileSchema {
+	return SBPoolSchema
+}
+
+// MarshalJSON encodes the profile, with SBPoolSchema as its @context if it has none
+func (p SBPoolProfile) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(p)
+}
+
+// UnmarshalJSON decodes the profile
+func (p *SBPoolProfile) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, p)
+}
+
+// SBRDataInfo wraps the rdataInfo object of a SBPoolProfile
+type SBRDataInfo struct {
+	State            string `json:"state"`
+	RunProbes        bool   `json:"runProbes"`
+	Priority         int    `json:"priority"`
+	FailoverDelay    int    `json:"failoverDelay,omitempty"`
+	Threshold        int    `json:"threshold"`
+	Weight           int    `json:"weight"`
+	AvailableToServe bool   `json:"availableToServe,omitempty"`
+
+	unknownMembers
+}
+
+// MarshalJSON encodes the SBRDataInfo
+func (v SBRDataInfo) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(v)
+}
+
+// UnmarshalJSON decodes the SBRDataInfo
+func (v *SBRDataInfo) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, v)
+}
+
+// BackupRecord wraps the backupRecord objects of an SBPoolProfile response
+type BackupRecord struct {
+	RData            string `json:"rdata,omitempty"`
+	FailoverDelay    int    `json:"failoverDelay,omitempty"`
+	AvailableToServe bool   `json:"availableToServe,omitempty"`
+
+	unknownMembers
+}
+
+// MarshalJSON encodes the BackupRecord
+func (v BackupRecord) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(v)
+}
+
+// UnmarshalJSON decodes the BackupRecord
+func (v *BackupRecord) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, v)
+}
+
+// TCPoolProfile wraps a Profile for a Traffic Controller pool
+type TCPoolProfile struct {
+	Context      ProfileSchema `json:"@context"`
+	Description  string        `json:"description"`
+	RunProbes    bool          `json:"runProbes"`
+	ActOnProbes  bool          `json:"actOnProbes"`
+	MaxToLB      int           `json:"maxToLB,omitempty"`
+	RDataInfo    []SBRDataInfo `json:"rdataInfo"`
+	BackupRecord *BackupRecord `json:"backupRecord,omitempty"`
+	Status       string        `json:"status,omitempty"`
+
+	unknownMembers
+}
+
+// Schema returns TCPoolSchema
+func (TCPoolProfile) Schema() ProfileSchema {
+	return TCPoolSchema
+}
+
+// MarshalJSON encodes the profile, with TCPoolSchema as its @context if it has none
+func (p TCPoolProfile) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(p)
+}
+
+// UnmarshalJSON decodes the profile
+func (p *TCPoolProfile) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, p)
+}
+
+// Here lie the values of the enumerated fields of SFPoolProfile and SLBPoolProfile
+const (
+	// RegionFailureSensitivity: HIGH fails over when any probing region fails, LOW when most do
+	SensitivityHigh = "HIGH"
+	SensitivityLow  = "LOW"
+
+	// LiveRecordState of a SFPoolProfile
+	LiveRecordForcedActive   = "FORCED_ACTIVE"
+	LiveRecordForcedInactive = "FORCED_INACTIVE"
+	LiveRecordNotForced      = "NOT_FORCED"
+
+	// ResponseMethod of a SLBPoolProfile
+	ResponsePriorityHunt = "PRIORITY_HUNT"
+	ResponseRandom       = "RANDOM"
+	ResponseRoundRobin   = "ROUND_ROBIN"
+
+	// ServingPreference of a SLBPoolProfile
+	ServeAutoSelect = "AUTO_SELECT"
+	ServePrimary    = "SERVE_PRIMARY"
+	ServeAllFail    = "SERVE_ALL_FAIL"
+)
+
+// Monitor wraps the monitor object of SFPoolProfile and SLBPoolProfile: the HTTP(S) check of the records
+type Monitor struct {
+	// Method is GET or POST
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// TransmittedData is the body of POST checks
+	TransmittedData string `json:"transmittedData,omitempty"`
+	// SearchString must be found in the response for the check to pass
+	SearchString string `json:"searchString,omitempty"`
+
+	unknownMembers
+}
+
+// MarshalJSON encodes the Monitor
+func (v Monitor) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(v)
+}
+
+// UnmarshalJSON decodes the Monitor
+func (v *Monitor) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, v)
+}
+
+// problems returns the inconsistencies of a monitor
+func (m Monitor) problems() []string {
+	problems := []string{}
+	switch m.Method {
+	case "GET":
+		if m.TransmittedData != "" {
+			problems = append(problems, "monitor transmittedData is only sent with POST")
+		}
+	case "POST":
+	default:
+		problems = append(problems, fmt.Sprintf("monitor method %q is not GET or POST", m.Method))
+	}
+	if u, err := url.Parse(m.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Sprintf("monitor url %q is not an absolute http or https URL", m.URL))
+	}
+	return problems
+}
+
+// SFBackupRecord wraps the backupRecord object of a SFPoolProfile
+type SFBackupRecord struct {
+	RData       string `json:"rdata"`
+	Description string `json:"description,omitempty"`
+
+	unknownMembers
+}
+
+// MarshalJSON encodes the SFBackupRecord
+func (v SFBackupRecord) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(v)
+}
+
+// UnmarshalJSON decodes the SFBackupRecord
+func (v *SFBackupRecord) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, v)
+}
+
+// SFPoolProfile wraps a Profile for a Simple Failover pool, serving its single record,
+// the live record, until the monitor fails, and the backup record from then on
+type SFPoolProfile struct {
+	Context                  ProfileSchema   `json:"@context"`
+	Description              string          `json:"description,omitempty"`
+	RegionFailureSensitivity string          `json:"regionFailureSensitivity"`
+	LiveRecordState          string          `json:"liveRecordState,omitempty"`
+	LiveRecordDescription    string          `json:"liveRecordDescription,omitempty"`
+	Monitor                  Monitor         `json:"monitor"`
+	BackupRecord             *SFBackupRecord `json:"backupRecord,omitempty"`
+	// Status is set by the API in responses
+	Status string `json:"status,omitempty"`
+
+	unknownMembers
+}
+
+// Schema returns SFPoolSchema
+func (SFPoolProfile) Schema() ProfileSchema {
+	return SFPoolSchema
+}
+
+// MarshalJSON encodes the profile, with SFPoolSchema as its @context if it has none
+func (p SFPoolProfile) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(p)
+}
+
+// UnmarshalJSON decodes the profile
+func (p *SFPoolProfile) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, p)
+}
+
+// problems returns the inconsistencies of the profile with an RRSet of n records
+func (p SFPoolProfile) problems(n int) []string {
+	problems := []string{}
+	if n != 1 {
+		problems = append(problems, fmt.Sprintf("a SF pool has one live record, not %d", n))
+	}
+	problems = append(problems, sensitivityProblems(p.RegionFailureSensitivity)...)
+	switch p.LiveRecordState {
+	case "", LiveRecordForcedActive, LiveRecordForcedInactive, LiveRecordNotForced:
+	default:
+		problems = append(problems, fmt.Sprintf("liveRecordState %q is not one of %s, %s, %s", p.LiveRecordState, LiveRecordForcedActive, LiveRecordForcedInactive, LiveRecordNotForced))
+	}
+	problems = append(problems, p.Monitor.problems()...)
+	if p.BackupRecord == nil || p.BackupRecord.RData == "" {
+		problems = append(problems, "a SF pool needs a backupRecord rdata")
+	}
+	return problems
+}
+
+// SLBRDataInfo wraps the rdataInfo object of a SLBPoolProfile
+type SLBRDataInfo struct {
+	Description    string `json:"description,omitempty"`
+	ProbingEnabled bool   `json:"probingEnabled"`
+
+	unknownMembers
+}
+
+// MarshalJSON encodes the SLBRDataInfo
+func (v SLBRDataInfo) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(v)
+}
+
+// UnmarshalJSON decodes the SLBRDataInfo
+func (v *SLBRDataInfo) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, v)
+}
+
+// AllFailRecord wraps the allFailRecord object of a SLBPoolProfile, the backup record served when every record fails
+type AllFailRecord struct {
+	RData       string `json:"rdata"`
+	Description string `json:"description,omitempty"`
+	// Serving is set by the API in responses
+	Serving bool `json:"serving,omitempty"`
+
+	unknownMembers
+}
+
+// MarshalJSON encodes the AllFailRecord
+func (v AllFailRecord) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(v)
+}
+
+// UnmarshalJSON decodes the AllFailRecord
+func (v *AllFailRecord) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, v)
+}
+
+// SLBPoolProfile wraps a Profile for a Simple Load Balancing pool
+type SLBPoolProfile struct {
+	Context                  ProfileSchema  `json:"@context"`
+	Description              string         `json:"description,omitempty"`
+	RegionFailureSensitivity string         `json:"regionFailureSensitivity"`
+	ResponseMethod           string         `json:"responseMethod"`
+	ServingPreference        string         `json:"servingPreference"`
+	Monitor                  Monitor        `json:"monitor"`
+	RDataInfo                []SLBRDataInfo `json:"rdataInfo"`
+	AllFailRecord            *AllFailRecord `json:"allFailRecord,omitempty"`
+	// Status is set by the API in responses
+	Status string `json:"status,omitempty"`
+
+	unknownMembers
+}
+
+// Schema returns SLBPoolSchema
+func (SLBPoolProfile) Schema() ProfileSchema {
+	return SLBPoolSchema
+}
+
+// MarshalJSON encodes the profile, with SLBPoolSchema as its @context if it has none
+func (p SLBPoolProfile) MarshalJSON() ([]byte, error) {
+	return marshalKeepingUnknown(p)
+}
+
+// UnmarshalJSON decodes the profile
+func (p *SLBPoolProfile) UnmarshalJSON(data []byte) error {
+	return unmarshalKeepingUnknown(data, p)
+}
+
+// problems returns the inconsistencies of the profile with an RRSet of n records
+func (p SLBPoolProfile) problems(n int) []string {
+	problems := []string{}
+	if len(p.RDataInfo) != n {
+		problems = append(problems, fmt.Sprintf("profile has %d rdataInfo for %d rdata", len(p.RDataInfo), n))
+	}
+	problems = append(problems, sensitivityProblems(p.RegionFailureSensitivity)...)
+	switch p.ResponseMethod {
+	case ResponsePriorityHunt, ResponseRandom, ResponseRoundRobin:
+	default:
+		problems = append(problems, fmt.Sprintf("responseMethod %q is not one of %s, %s, %s", p.ResponseMethod, ResponsePriorityHunt, ResponseRandom, ResponseRoundRobin))
+	}
+	switch p.ServingPreference {
+	case ServeAutoSelect, ServePrimary, ServeAllFail:
+	default:
+		problems = append(problems, fmt.Sprintf("servingPreference %q is not one of %s, %s, %s", p.ServingPreference, ServeAutoSelect, ServePrimary, ServeAllFail))
+	}
+	problems = append(problems, p.Monitor.problems()...)
+	if p.AllFailRecord == nil || p.AllFailRecord.RData == "" {
+		problems = append(problems, "a SLB pool needs an allFailRecord rdata")
+	}
+	return problems
+}
+
+// sensitivityProblems checks a regionFailureSensitivity
+func sensitivityProblems(s string) []string {
+	if s != SensitivityHigh && s != SensitivityLow {
+		return []string{fmt.Sprintf("regionFailureSensitivity %q is not %s or %s", s, SensitivityHigh, SensitivityLow)}
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package udnssdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// compactJSON strips the whitespace of s, for comparisons with json.Marshal output
+func compactJSON(t *testing.T, s string) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, []byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func Test_RRSet_UnmarshalJSON_Profiles(t *testing.T) {
+	cases := []struct {
+		profile string
+		want    Profile
+	}{
+		{
+			`{"@context":"http://schemas.ultradns.com/SBPool.jsonschema","description":"pool","runProbes":true,"actOnProbes":true,"order":"ROUND_ROBIN","maxActive":1,` +
+				`"rdataInfo":[{"state":"NORMAL","runProbes":true,"priority":1,"threshold":1,"weight":100}],` +
+				`"backupRecords":[{"rdata":"192.0.2.9","failoverDelay":1}]}`,
+			SBPoolProfile{
+				Context: SBPoolSchema, Description: "pool", RunProbes: true, ActOnProbes: true, Order: "ROUND_ROBIN", MaxActive: 1,
+				RDataInfo:     []SBRDataInfo{{State: "NORMAL", RunProbes: true, Priority: 1, Threshold: 1, Weight: 100}},
+				BackupRecords: []BackupRecord{{RData: "192.0.2.9", FailoverDelay: 1}},
+			},
+		},
+		{
+			`{"@context":"http://schemas.ultradns.com/DirPool.jsonschema","description":"","rdataInfo":[{"geoInfo":{"name":"eu","codes":["EUR"]}}],"noResponse":{"allNonConfigured":true}}`,
+			DirPoolProfile{
+				Context:    DirPoolSchema,
+				RDataInfo:  []DPRDataInfo{{GeoInfo: &GeoInfo{Name: "eu", Codes: []string{"EUR"}}}},
+				NoResponse: DPRDataInfo{AllNonConfigured: true},
+			},
+		},
+		{
+			`{"@context":"http://schemas.ultradns.com/TCPool.jsonschema","description":"","runProbes":false,"actOnProbes":false,"rdataInfo":[],"backupRecord":{"rdata":"192.0.2.9"}}`,
+			TCPoolProfile{Context: TCPoolSchema, RDataInfo: []SBRDataInfo{}, BackupRecord: &BackupRecord{RData: "192.0.2.9"}},
+		},
+		{
+			`{"@context":"http://schemas.ultradns.com/RDPool.jsonschema","order":"RANDOM","description":""}`,
+			RDPoolProfile{Context: RDPoolSchema, Order: "RANDOM"},
+		},
+	}
+	for _, c := range cases {
+		in := `{"ownerName":"pool.example.com.","rrtype":"A (1)","ttl":300,"rdata":["192.0.2.1"],"profile":` + c.profile + `}`
+		var rr RRSet
+		if err := json.Unmarshal([]byte(in), &rr); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", in, err)
+		}
+		if !reflect.DeepEqual(rr.Profile, c.want) {
+			t.Errorf("Profile: %#v, want: %#v", rr.Profile, c.want)
+		}
+		if rr.OwnerName != "pool.example.com." || rr.TTL != 300 {
+			t.Errorf("RRSet: %+v", rr)
+		}
+
+		out, err := json.Marshal(rr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != in {
+			t.Errorf("Marshal:\n%s\nwant:\n%s", out, in)
+		}
+	}
+}
+
+func Test_RRSet_UnmarshalJSON_UnknownProfile(t *testing.T) {
+	profile := `{"@context": "http://schemas.ultradns.com/Future.jsonschema", "weight": 1.50, "nested": {"b": 1, "a": [true]}}`
+	in := `{"ownerName":"pool","rrtype":"A","ttl":300,"rdata":["192.0.2.1"],"profile":` + profile + `}`
+
+	var rr RRSet
+	if err := json.Unmarshal([]byte(in), &rr); err != nil {
+		t.Fatal(err)
+	}
+	p, ok := rr.Profile.(UnknownProfile)
+	if !ok {
+		t.Fatalf("Profile: %#v, want an UnknownProfile", rr.Profile)
+	}
+	if p.Schema() != "http://schemas.ultradns.com/Future.jsonschema" {
+		t.Errorf("Schema: %q", p.Schema())
+	}
+
+	out, err := json.Marshal(rr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := compactJSON(t, in); string(out) != want {
+		t.Errorf("Marshal:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func Test_RRSet_UnmarshalJSON_NoProfile(t *testing.T) {
+	for _, in := range []string{
+		`{"ownerName":"www","rrtype":"A","ttl":300,"rdata":["192.0.2.1"]}`,
+		`{"ownerName":"www","rrtype":"A","ttl":300,"rdata":["192.0.2.1"],"profile":null}`,
+	} {
+		var rr RRSet
+		if err := json.Unmarshal([]byte(in), &rr); err != nil {
+			t.Fatal(err)
+		}
+		if rr.Profile != nil {
+			t.Errorf("Profile: %#v, want: nil", rr.Profile)
+		}
+		out, _ := json.Marshal(rr)
+		if want := `{"ownerName":"www","rrtype":"A","ttl":300,"rdata":["192.0.2.1"]}`; string(out) != want {
+			t.Errorf("Marshal: %s, want: %s", out, want)
+		}
+	}
+}
+
+func Test_ParseProfile_Error(t *testing.T) {
+	if _, err := ParseProfile([]byte(`{"@context":"http://schemas.ultradns.com/SBPool.jsonschema","maxActive":"one"}`)); err == nil {
+		t.Error("ParseProfile: no error for a mistyped field")
+	}
+}
+
+func Test_RawProfile_Profile(t *testing.T) {
+	rp := RawProfile{"@context": string(RDPoolSchema), "order": "FIXED", "description": "d"}
+	p, err := rp.Profile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := RDPoolProfile{Context: RDPoolSchema, Order: "FIXED", Description: "d"}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("Profile: %#v, want: %#v", p, want)
+	}
+	if !sameProfile(rp, want) {
+		t.Errorf("sameProfile(%v, %v): false", rp, want)
+	}
+	if sameProfile(rp, RDPoolProfile{Order: "RANDOM"}) {
+		t.Errorf("sameProfile: true for different orders")
+	}
+}
+
+func Test_TypedProfile_RawProfile_Pointers(t *testing.T) {
+	p := TCPoolProfile{RDataInfo: []SBRDataInfo{}, BackupRecord: &BackupRecord{RData: "192.0.2.9", FailoverDelay: 2}}
+	expected := RawProfile{
+		"@context":     string(TCPoolSchema),
+		"description":  "",
+		"runProbes":    false,
+		"actOnProbes":  false,
+		"rdataInfo":    []interface{}{},
+		"backupRecord": map[string]interface{}{"rdata": "192.0.2.9", "failoverDelay": float64(2)},
+	}
+	if actual := p.RawProfile(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("RawProfile: %#v, want: %#v", actual, expected)
+	}
+}
+
+func Test_RRSet_UnmarshalJSON_SFAndSLBProfiles(t *testing.T) {
+	cases := []struct {
+		profile string
+		want    Profile
+	}{
+		{
+			`{"@context":"http://schemas.ultradns.com/SFPool.jsonschema","description":"www","regionFailureSensitivity":"HIGH","liveRecordState":"NOT_FORCED","liveRecordDescription":"primary",` +
+				`"monitor":{"method":"POST","url":"https://192.0.2.1/health","transmittedData":"ping","searchString":"ok"},"backupRecord":{"rdata":"192.0.2.9","description":"backup"},"status":"OK"}`,
+			SFPoolProfile{
+				Context: SFPoolSchema, Description: "www", RegionFailureSensitivity: SensitivityHigh,
+				LiveRecordState: LiveRecordNotForced, LiveRecordDescription: "primary",
+				Monitor:      Monitor{Method: "POST", URL: "https://192.0.2.1/health", TransmittedData: "ping", SearchString: "ok"},
+				BackupRecord: &SFBackupRecord{RData: "192.0.2.9", Description: "backup"},
+				Status:       "OK",
+			},
+		},
+		{
+			`{"@context":"http://schemas.ultradns.com/SLBPool.jsonschema","regionFailureSensitivity":"LOW","responseMethod":"ROUND_ROBIN","servingPreference":"AUTO_SELECT",` +
+				`"monitor":{"method":"GET","url":"http://example.com/"},"rdataInfo":[{"description":"one","probingEnabled":true},{"probingEnabled":false}],"allFailRecord":{"rdata":"192.0.2.9","serving":true}}`,
+			SLBPoolProfile{
+				Context: SLBPoolSchema, RegionFailureSensitivity: SensitivityLow, ResponseMethod: ResponseRoundRobin, ServingPreference: ServeAutoSelect,
+				Monitor:       Monitor{Method: "GET", URL: "http://example.com/"},
+				RDataInfo:     []SLBRDataInfo{{Description: "one", ProbingEnabled: true}, {}},
+				AllFailRecord: &AllFailRecord{RData: "192.0.2.9", Serving: true},
+			},
+		},
+	}
+	for _, c := range cases {
+		in := `{"ownerName":"www","rrtype":"A","ttl":300,"rdata":["192.0.2.1"],"profile":` + c.profile + `}`
+		var rr RRSet
+		if err := json.Unmarshal([]byte(in), &rr); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", in, err)
+		}
+		if !reflect.DeepEqual(rr.Profile, c.want) {
+			t.Errorf("Profile: %#v, want: %#v", rr.Profile, c.want)
+		}
+		out, err := json.Marshal(rr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != in {
+			t.Errorf("Marshal:\n%s\nwant:\n%s", out, in)
+		}
+
+		rp, err := ToRawProfile(rr.Profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := rp.GetProfileObject()
+		if err != nil {
+			t.Fatalf("GetProfileObject: %v", err)
+		}
+		if !reflect.DeepEqual(p, c.want) {
+			t.Errorf("GetProfileObject: %#v, want: %#v", p, c.want)
+		}
+	}
+}
+
+func Test_RRSet_UnknownProfileMembers(t *testing.T) {
+	in := `{"ownerName":"pool.example.com.","rrtype":"A (1)","ttl":300,"rdata":["192.0.2.1"],"profile":{
+		"@context":"http://schemas.ultradns.com/SBPool.jsonschema","runProbes":true,"actOnProbes":true,"order":"FIXED","maxActive":1,
+		"newSetting":{"enabled":true},
+		"rdataInfo":[{"state":"NORMAL","runProbes":true,"priority":1,"threshold":1,"weight":2,"region":"eu"}],
+		"backupRecords":[{"rdata":"192.0.2.9","failoverDelay":5,"tier":2}]}}`
+	var rr RRSet
+	if err := json.Unmarshal([]byte(in), &rr); err != nil {
+		t.Fatal(err)
+	}
+	p, ok := rr.Profile.(SBPoolProfile)
+	if !ok {
+		t.Fatalf("Profile: %T", rr.Profile)
+	}
+
+	// known fields that are changed or cleared are sent as they are now, unknown members as they were received
+	p.MaxActive = 0
+	p.RDataInfo[0].Weight = 3
+	rr.Profile = p
+	b, err := json.Marshal(rr.Profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := compactJSON(t, `{"@context":"http://schemas.ultradns.com/SBPool.jsonschema","actOnProbes":true,
+		"backupRecords":[{"failoverDelay":5,"rdata":"192.0.2.9","tier":2}],"description":"","newSetting":{"enabled":true},"order":"FIXED",
+		"rdataInfo":[{"priority":1,"region":"eu","runProbes":true,"state":"NORMAL","threshold":1,"weight":3}],"runProbes":true}`)
+	if string(b) != want {
+		t.Errorf("Marshal:\n%s\nwant:\n%s", b, want)
+	}
+
+	rp, err := ToRawProfile(rr.Profile)
+	if err != nil || rp["newSetting"] == nil {
+		t.Errorf("ToRawProfile: %v, %v", rp, err)
+	}
+
+	var plain SBPoolProfile
+	if err := json.Unmarshal([]byte(`{"@context":"http://schemas.ultradns.com/SBPool.jsonschema","runProbes":true,"actOnProbes":false,"RDataInfo":[]}`), &plain); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(plain, SBPoolProfile{Context: SBPoolSchema, RunProbes: true, RDataInfo: []SBRDataInfo{}}) {
+		t.Errorf("profile without unknown members: %#v", plain)
+	}
+}
+
+func Test_RawProfile_GetProfileObject_UnknownMembers(t *testing.T) {
+	in := `{"@context":"http://schemas.ultradns.com/DirPool.jsonschema","description":"pool","conflictResolve":"GEO","newSetting":{"enabled":true},` +
+		`"rdataInfo":[{"geoInfo":{"name":"eu","codes":["EUR"],"tier":2}},{"allNonConfigured":true}]}`
+	var rp RawProfile
+	if err := json.Unmarshal([]byte(in), &rp); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := rp.GetProfileObject()
+	if err != nil {
+		t.Fatalf("GetProfileObject: %v", err)
+	}
+	dp, ok := p.(DirPoolProfile)
+	if !ok || dp.ConflictResolve != "GEO" || len(dp.RDataInfo) != 2 || dp.RDataInfo[0].GeoInfo.Name != "eu" {
+		t.Fatalf("GetProfileObject: %#v", p)
+	}
+	back, err := ToRawProfile(dp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, rp) {
+		t.Errorf("round trip: %#v, want: %#v", back, rp)
+	}
+
+	rr := RRSet{OwnerName: "pool.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1", "192.0.2.2"}, Profile: rp}
+	if err := rr.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package udnssdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Here lie the kinds of RRSets a Query can select
+const (
+	AllKind      = "ALL"
+	RecordsKind  = "RECORDS"
+	PoolsKind    = "POOLS"
+	RDPoolsKind  = "RD_POOLS"
+	DirPoolsKind = "DIR_POOLS"
+	SBPoolsKind  = "SB_POOLS"
+	TCPoolsKind  = "TC_POOLS"
+	SFPoolsKind  = "SF_POOLS"
+	SLBPoolsKind = "SLB_POOLS"
+)
+
+// Query filters, orders and sizes the results of a Select.
+// The filters are sent as the q parameter, a space-separated list of "name:value" terms;
+// the zero Query selects everything in the API's default order.
+type Query struct {
+	// Kind selects RRSets of one kind, e.g. RecordsKind or SBPoolsKind
+	Kind string
+	// Owner matches the owner names of RRSets
+	Owner string
+	// Value matches the rdata of RRSets, e.g. the target of CNAMEs
+	Value string
+	// TTL matches RRSets with this TTL; zero matches any
+	TTL int
+	// Type matches record types, e.g. "CNAME"
+	Type string
+	// Name matches the names of zones, directional groups and other named resources
+	Name string
+	// ZoneType matches zones of this type
+	ZoneType ZoneType
+	// ZoneStatus matches zones by status: ACTIVE, SUSPENDED or ALL
+	ZoneStatus string
+	// AccountName matches zones of this account
+	AccountName string
+	// Terms are added to the q parameter as they are, as with the query strings of earlier versions
+	Terms string
+
+	// Sort is the field results are ordered by, e.g. OWNER, TTL or TYPE for RRSets
+	Sort    string
+	Reverse bool
+	// Limit is the page size; the API defaults to 100
+	Limit int
+}
+
+// QueryInfo returns the API query described by q
+func (q Query) QueryInfo() QueryInfo {
+	terms := []string{}
+	add := func(name, value string) {
+		if value != "" {
+			terms = append(terms, name+":"+value)
+		}
+	}
+	add("kind", q.Kind)
+	add("owner", q.Owner)
+	add("value", q.Value)
+	if q.TTL != 0 {
+		add("ttl", fmt.Sprint(q.TTL))
+	}
+	add("type", q.Type)
+	add("name", q.Name)
+	add("zone_type", string(q.ZoneType))
+	add("zone_status", q.ZoneStatus)
+	add("account_name", q.AccountName)
+	if q.Terms != "" {
+		terms = append(terms, q.Terms)
+	}
+	return QueryInfo{Q: strings.Join(terms, " "), Sort: q.Sort, Reverse: q.Reverse, Limit: q.Limit}
+}
+
+// Values returns the URL query parameters of q
+func (q Query) Values() url.Values {
+	qi := q.QueryInfo()
+	v := url.Values{}
+	if qi.Q != "" {
+		v.Set("q", qi.Q)
+	}
+	if qi.Sort != "" {
+		v.Set("sort", qi.Sort)
+	}
+	if qi.Reverse {
+		v.Set("reverse", "true")
+	}
+	if qi.Limit != 0 {
+		v.Set("limit", fmt.Sprint(qi.Limit))
+	}
+	return v
+}
+
+// URI appends the escaped parameters of q, and offset unless it is negative, to uri
+func (q Query) URI(uri string, offset int) string {
+	v := q.Values()
+	if offset >= 0 {
+		v.Set("offset", fmt.Sprint(offset))
+	}
+	if len(v) == 0 {
+		return uri
+	}
+	return fmt.Sprintf("%s?%s", uri, v.Encode())
+}
+
+// newQueryPager returns a Pager over the results of q, requested with fetch.
+// The Pager's Query starts with the Sort, Reverse and Limit of q, and replaces them in each request.
+func newQueryPager[T any](c *Client, q Query, fetch func(ctx context.Context, q Query, offset int) ([]T, ResultInfo, *http.Response, error)) *Pager[T] {
+	p := NewPager(c, func(ctx context.Context, qi QueryInfo, offset int) ([]T, ResultInfo, *http.Response, error) {
+		q.Sort, q.Reverse, q.Limit = qi.Sort, qi.Reverse, qi.Limit
+		return fetch(ctx, q, offset)
+	})
+	p.Query = QueryInfo{Sort: q.Sort, Reverse: q.Reverse, Limit: q.Limit}
+	return p
+}
@@ -0,0 +1,61 @@
+package udnssdk
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_Query_URI(t *testing.T) {
+	k := RRSetKey{Zone: "example.com."}
+	cases := []struct {
+		uri  string
+		want string
+	}{
+		{k.queryURI(Query{}, 0), "zones/example.com./rrsets/ANY?offset=0"},
+		{k.queryURI(Query{Kind: RecordsKind, Type: "CNAME", Value: "www.example.com.", Sort: "OWNER", Reverse: true, Limit: 50}, 100),
+			"zones/example.com./rrsets/ANY?limit=50&offset=100&q=kind%3ARECORDS+value%3Awww.example.com.+type%3ACNAME&reverse=true&sort=OWNER"},
+		{k.queryURI(Query{Owner: "a&b", TTL: 300}, 0), "zones/example.com./rrsets/ANY?offset=0&q=owner%3Aa%26b+ttl%3A300"},
+		{Query{}.URI(k.AlertsURI(), 0), "zones/example.com./rrsets/alerts?offset=0"},
+		{Query{Terms: "name:x y"}.URI(k.EventsURI(), 2), "zones/example.com./rrsets/events?offset=2&q=name%3Ax+y"},
+		{Query{Sort: "NAME"}.URI(k.NotificationsURI(), 0), "zones/example.com./rrsets/notifications?offset=0&sort=NAME"},
+		{Query{}.URI(k.ProbesURI(), -1), "zones/example.com./rrsets/probes"},
+		{Query{Limit: 5}.URI(k.ProbesURI(), -1), "zones/example.com./rrsets/probes?limit=5"},
+		{Query{Terms: "status:COMPLETE"}.URI("tasks", 0), "tasks?offset=0&q=status%3ACOMPLETE"},
+	}
+	for _, c := range cases {
+		if c.uri != c.want {
+			t.Errorf("URI: %q, want: %q", c.uri, c.want)
+		}
+	}
+}
+
+func Test_Query_QueryInfo(t *testing.T) {
+	q := Query{Kind: PoolsKind, Owner: "www", Name: "geo1", Terms: "extra:1", Sort: "TTL", Limit: 10}
+	want := QueryInfo{Q: "kind:POOLS owner:www name:geo1 extra:1", Sort: "TTL", Limit: 10}
+	if qi := q.QueryInfo(); qi != want {
+		t.Errorf("QueryInfo: %+v, want: %+v", qi, want)
+	}
+}
+
+func Test_TasksService_SelectQuery(t *testing.T) {
+	var got []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.URL.RawQuery)
+		fmt.Fprintln(w, `{"tasks":[],"resultInfo":{"totalCount":0,"offset":0,"returnedCount":0}}`)
+	}))
+	defer ts.Close()
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+
+	if _, err := testClient.Tasks.Select("status:COMPLETE"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := testClient.Tasks.SelectQuery(Query{Terms: "status:COMPLETE", Limit: 5}); err != nil {
+		t.Fatal(err)
+	}
+	want := "[sort=NAME&query=status:COMPLETE&offset=0 limit=5&offset=0&q=status%3ACOMPLETE]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("queries: %v, want: %s", got, want)
+	}
+}
@@ -0,0 +1,443 @@
+package udnssdk
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// RData is a typed record of an RRSet. String returns the record in the
+// UltraDNS wire format used in RRSet.RData, which ParseRData reads back.
+type RData interface {
+	// RRType returns the record type mnemonic, e.g. "MX"
+	RRType() string
+	String() string
+}
+
+// ARData is an IPv4 address record
+type ARData struct {
+	Address netip.Addr
+}
+
+// AAAARData is an IPv6 address record
+type AAAARData struct {
+	Address netip.Addr
+}
+
+// CNAMERData is a canonical name record
+type CNAMERData struct {
+	Target string
+}
+
+// NSRData is a name server record
+type NSRData struct {
+	Host string
+}
+
+// PTRRData is a domain name pointer record
+type PTRRData struct {
+	Target string
+}
+
+// MXRData is a mail exchange record
+type MXRData struct {
+	Preference uint16
+	Exchange   string
+}
+
+// SRVRData is a service location record
+type SRVRData struct {
+	Priority uint16
+	Weight   uint16
+	Port     uint16
+	Target   string
+}
+
+// TXTRData is a text record. UltraDNS carries the text unquoted.
+type TXTRData struct {
+	Text string
+}
+
+// SPFRData is a sender policy framework record
+type SPFRData struct {
+	Text string
+}
+
+// CAARData is a certification authority authorization record
+type CAARData struct {
+	Flags uint8
+	Tag   string
+	Value string
+}
+
+// SOARData is a start of authority record
+type SOARData struct {
+	MName   string
+	RName   string
+	Serial  uint32
+	Refresh uint32
+	Retry   uint32
+	Expire  uint32
+	Minimum uint32
+}
+
+// NAPTRRData is a naming authority pointer record
+type NAPTRRData struct {
+	Order       uint16
+	Preference  uint16
+	Flags       string
+	Services    string
+	Regexp      string
+	Replacement string
+}
+
+// SSHFPRData is an SSH public key fingerprint record; Fingerprint is hex encoded
+type SSHFPRData struct {
+	Algorithm   uint8
+	Type        uint8
+	Fingerprint string
+}
+
+// TLSARData is a TLS certificate association record; Certificate is hex encoded
+type TLSARData struct {
+	Usage        uint8
+	Selector     uint8
+	MatchingType uint8
+	Certificate  string
+}
+
+// DSRData is a delegation signer record; Digest is hex encoded
+type DSRData struct {
+	KeyTag     uint16
+	Algorithm  uint8
+	DigestType uint8
+	Digest     string
+}
+
+// RRType returns "A"
+func (ARData) RRType() string { return "A" }
+
+// RRType returns "AAAA"
+func (AAAARData) RRType() string { return "AAAA" }
+
+// RRType returns "CNAME"
+func (CNAMERData) RRType() string { return "CNAME" }
+
+// RRType returns "NS"
+func (NSRData) RRType() string { return "NS" }
+
+// RRType returns "PTR"
+func (PTRRData) RRType() string { return "PTR" }
+
+// RRType returns "MX"
+func (MXRData) RRType() string { return "MX" }
+
+// RRType returns "SRV"
+func (SRVRData) RRType() string { return "SRV" }
+
+// RRType returns "TXT"
+func (TXTRData) RRType() string { return "TXT" }
+
+// RRType returns "SPF"
+func (SPFRData) RRType() string { return "SPF" }
+
+// RRType returns "CAA"
+func (CAARData) RRType() string { return "CAA" }
+
+// RRType returns "SOA"
+func (SOARData) RRType() string { return "SOA" }
+
+// RRType returns "NAPTR"
+func (NAPTRRData) RRType() string { return "NAPTR" }
+
+// RRType returns "SSHFP"
+func (SSHFPRData) RRType() string { return "SSHFP" }
+
+// RRType returns "TLSA"
+func (TLSARData) RRType() string { return "TLSA" }
+
+// RRType returns "DS"
+func (DSRData) RRType() string { return "DS" }
+
+// String returns the address
+func (r ARData) String() string { return r.Address.String() }
+
+// String returns the address
+func (r AAAARData) String() string { return r.Address.String() }
+
+// String returns the target name
+func (r CNAMERData) String() string { return r.Target }
+
+// String returns the name server
+func (r NSRData) String() string { return r.Host }
+
+// String returns the target name
+func (r PTRRData) String() string { return r.Target }
+
+// String returns the unquoted text
+func (r TXTRData) String() string { return r.Text }
+
+// String returns the unquoted text
+func (r SPFRData) String() string { return r.Text }
+
+// String returns "preference exchange"
+func (r MXRData) String() string {
+	return fmt.Sprintf("%d %s", r.Preference, r.Exchange)
+}
+
+// String returns "priority weight port target"
+func (r SRVRData) String() string {
+	return fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, r.Target)
+}
+
+// String returns "flags tag value", with the value quoted
+func (r CAARData) String() string {
+	return fmt.Sprintf("%d %s %s", r.Flags, r.Tag, quoteCharacterString(r.Value))
+}
+
+// String returns "mname rname serial refresh retry expire minimum"
+func (r SOARData) String() string {
+	return fmt.Sprintf("%s %s %d %d %d %d %d", r.MName, r.RName, r.Serial, r.Refresh, r.Retry, r.Expire, r.Minimum)
+}
+
+// String returns "order preference flags services regexp replacement", with the strings quoted
+func (r NAPTRRData) String() string {
+	return fmt.Sprintf("%d %d %s %s %s %s", r.Order, r.Preference,
+		quoteCharacterString(r.Flags), quoteCharacterString(r.Services), quoteCharacterString(r.Regexp), r.Replacement)
+}
+
+// String returns "algorithm type fingerprint"
+func (r SSHFPRData) String() string {
+	return fmt.Sprintf("%d %d %s", r.Algorithm, r.Type, r.Fingerprint)
+}
+
+// String returns "usage selector matching-type certificate"
+func (r TLSARData) String() string {
+	return fmt.Sprintf("%d %d %d %s", r.Usage, r.Selector, r.MatchingType, r.Certificate)
+}
+
+// String returns "key-tag algorithm digest-type digest"
+func (r DSRData) String() string {
+	return fmt.Sprintf("%d %d %d %s", r.KeyTag, r.Algorithm, r.DigestType, r.Digest)
+}
+
+// errUnsupportedRRType is returned by ParseRData for record types without an RData type
+var errUnsupportedRRType = errors.New("unsupported record type")
+
+// ParseRData parses s, in UltraDNS wire format, as a record of rrtype.
+// rrtype may be given as returned by the API, e.g. "MX (15)".
+func ParseRData(rrtype, s string) (RData, error) {
+	t := rrTypeName(rrtype)
+	r, err := parseRDataFields(t, s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s rdata %q: %w", t, s, err)
+	}
+	return r, nil
+}
+
+func parseRDataFields(t, s string) (RData, error) {
+	switch t {
+	case "TXT":
+		return TXTRData{Text: s}, nil
+	case "SPF":
+		return SPFRData{Text: s}, nil
+	}
+
+	f, err := splitRData(s)
+	if err != nil {
+		return nil, err
+	}
+	p := rdataParser{fields: f}
+
+	var r RData
+	switch t {
+	case "A":
+		a := p.addr()
+		if p.err == nil && !a.Is4() {
+			p.err = fmt.Errorf("not an IPv4 address")
+		}
+		r = ARData{Address: a}
+	case "AAAA":
+		a := p.addr()
+		if p.err == nil && !a.Is6() {
+			p.err = fmt.Errorf("not an IPv6 address")
+		}
+		r = AAAARData{Address: a}
+	case "CNAME":
+		r = CNAMERData{Target: p.name()}
+	case "NS":
+		r = NSRData{Host: p.name()}
+	case "PTR":
+		r = PTRRData{Target: p.name()}
+	case "MX":
+		r = MXRData{Preference: p.uint16(), Exchange: p.name()}
+	case "SRV":
+		r = SRVRData{Priority: p.uint16(), Weight: p.uint16(), Port: p.uint16(), Target: p.name()}
+	case "CAA":
+		r = CAARData{Flags: p.uint8(), Tag: p.word(), Value: p.text()}
+	case "SOA":
+		r = SOARData{MName: p.name(), RName: p.name(), Serial: p.uint32(), Refresh: p.uint32(), Retry: p.uint32(), Expire: p.uint32(), Minimum: p.uint32()}
+	case "NAPTR":
+		r = NAPTRRData{Order: p.uint16(), Preference: p.uint16(), Flags: p.text(), Services: p.text(), Regexp: p.text(), Replacement: p.name()}
+	case "SSHFP":
+		r = SSHFPRData{Algorithm: p.uint8(), Type: p.uint8(), Fingerprint: p.hex()}
+	case "TLSA":
+		r = TLSARData{Usage: p.uint8(), Selector: p.uint8(), MatchingType: p.uint8(), Certificate: p.hex()}
+	case "DS":
+		r = DSRData{KeyTag: p.uint16(), Algorithm: p.uint8(), DigestType: p.uint8(), Digest: p.hex()}
+	default:
+		return nil, errUnsupportedRRType
+	}
+	if p.err == nil && len(p.fields) != 0 {
+		p.err = fmt.Errorf("unexpected %q", strings.Join(p.fields, " "))
+	}
+	return r, p.err
+}
+
+// rdataParser consumes the fields of an rdata string, keeping the first error
+type rdataParser struct {
+	fields []string
+	err    error
+}
+
+func (p *rdataParser) next() (string, bool) {
+	if p.err != nil {
+		return "", false
+	}
+	if len(p.fields) == 0 {
+		p.err = fmt.Errorf("too few fields")
+		return "", false
+	}
+	f := p.fields[0]
+	p.fields = p.fields[1:]
+	return f, true
+}
+
+func (p *rdataParser) uint(bits int) uint64 {
+	f, ok := p.next()
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseUint(f, 10, bits)
+	if err != nil {
+		p.err = fmt.Errorf("%q is not a %d-bit unsigned integer", f, bits)
+	}
+	return n
+}
+
+func (p *rdataParser) uint8() uint8   { return uint8(p.uint(8)) }
+func (p *rdataParser) uint16() uint16 { return uint16(p.uint(16)) }
+func (p *rdataParser) uint32() uint32 { return uint32(p.uint(32)) }
+
+func (p *rdataParser) word() string {
+	f, _ := p.next()
+	return f
+}
+
+func (p *rdataParser) name() string {
+	f, ok := p.next()
+	if ok && strings.HasPrefix(f, `"`) {
+		p.err = fmt.Errorf("%s is not a domain name", f)
+	}
+	return f
+}
+
+func (p *rdataParser) text() string {
+	f, _ := p.next()
+	return unquoteCharacterString(f)
+}
+
+func (p *rdataParser) addr() netip.Addr {
+	f, ok := p.next()
+	if !ok {
+		return netip.Addr{}
+	}
+	a, err := netip.ParseAddr(f)
+	if err != nil {
+		p.err = err
+	}
+	return a
+}
+
+// hex consumes the remaining fields as one hex string, which zone files may split with spaces
+func (p *rdataParser) hex() string {
+	if p.err != nil {
+		return ""
+	}
+	h := strings.Join(p.fields, "")
+	p.fields = nil
+	if h == "" {
+		p.err = fmt.Errorf("too few fields")
+	} else if _, err := hex.DecodeString(h); err != nil {
+		p.err = fmt.Errorf("%q is not hex encoded", h)
+	}
+	return h
+}
+
+// splitRData splits rdata into whitespace separated fields, keeping quoted character-strings,
+// quotes included, as single fields
+func splitRData(s string) ([]string, error) {
+	fields := []string{}
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '"':
+			j := i + 1
+			for ; j < len(s) && s[j] != '"'; j++ {
+				if s[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated quoted string")
+			}
+			fields = append(fields, s[i:j+1])
+			i = j + 1
+		default:
+			j := i
+			for j < len(s) && s[j] != ' ' && s[j] != '\t' {
+				j++
+			}
+			fields = append(fields, s[i:j])
+			i = j
+		}
+	}
+	return fields, nil
+}
+
+// Records parses the RData of r as typed records
+func (r RRSet) Records() ([]RData, error) {
+	records := make([]RData, 0, len(r.RData))
+	for _, s := range r.RData {
+		rd, err := ParseRData(r.RRType, s)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rd)
+	}
+	return records, nil
+}
+
+// Type returns the record type mnemonic of r, e.g. "A" for an RRType of "A (1)"
+func (r RRSet) Type() string {
+	return rrTypeName(r.RRType)
+}
+
+// NewRRSet returns an RRSet of owner holding records, which must all be of the same type
+func NewRRSet(owner string, ttl int, records ...RData) (RRSet, error) {
+	if len(records) == 0 {
+		return RRSet{}, fmt.Errorf("RRSet %s requires at least one record", owner)
+	}
+	rr := RRSet{OwnerName: owner, RRType: records[0].RRType(), TTL: ttl}
+	for _, rd := range records {
+		if rd.RRType() != rr.RRType {
+			return RRSet{}, fmt.Errorf("RRSet %s %s cannot hold a %s record", owner, rr.RRType, rd.RRType())
+		}
+		rr.RData = append(rr.RData, rd.String())
+	}
+	return rr, nil
+}
@@ -0,0 +1,128 @@
+package udnssdk
+
+import (
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_ParseRData_RoundTrip(t *testing.T) {
+	cases := []struct {
+		rrtype string
+		wire   string
+		want   RData
+	}{
+		{"A (1)", "192.0.2.1", ARData{Address: netip.MustParseAddr("192.0.2.1")}},
+		{"AAAA", "2001:db8::1", AAAARData{Address: netip.MustParseAddr("2001:db8::1")}},
+		{"CNAME", "www.example.com.", CNAMERData{Target: "www.example.com."}},
+		{"NS", "pdns1.ultradns.net.", NSRData{Host: "pdns1.ultradns.net."}},
+		{"PTR", "host.example.com.", PTRRData{Target: "host.example.com."}},
+		{"MX (15)", "10 mail.example.com.", MXRData{Preference: 10, Exchange: "mail.example.com."}},
+		{"SRV", "10 60 5060 sip.example.com.", SRVRData{Priority: 10, Weight: 60, Port: 5060, Target: "sip.example.com."}},
+		{"TXT", `v=spf1 include:"x" -all`, TXTRData{Text: `v=spf1 include:"x" -all`}},
+		{"SPF", "v=spf1 -all", SPFRData{Text: "v=spf1 -all"}},
+		{"CAA", `0 issue "letsencrypt.org"`, CAARData{Flags: 0, Tag: "issue", Value: "letsencrypt.org"}},
+		{"SOA", "pdns1.ultradns.net. admin.example.com. 2024010101 86400 86400 86400 86400",
+			SOARData{MName: "pdns1.ultradns.net.", RName: "admin.example.com.", Serial: 2024010101, Refresh: 86400, Retry: 86400, Expire: 86400, Minimum: 86400}},
+		{"NAPTR", `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`,
+			NAPTRRData{Order: 100, Preference: 10, Flags: "U", Services: "E2U+sip", Regexp: "!^.*$!sip:info@example.com!", Replacement: "."}},
+		{"SSHFP", "1 2 123456789ABCDEF67890123456789ABCDEF67890123456789ABCDEF123456789AB",
+			SSHFPRData{Algorithm: 1, Type: 2, Fingerprint: "123456789ABCDEF67890123456789ABCDEF67890123456789ABCDEF123456789AB"}},
+		{"TLSA", "3 1 1 0123456789ABCDEF", TLSARData{Usage: 3, Selector: 1, MatchingType: 1, Certificate: "0123456789ABCDEF"}},
+		{"DS", "60485 5 1 2BB183AF5F22588179A53B0A98631FAD1A292118", DSRData{KeyTag: 60485, Algorithm: 5, DigestType: 1, Digest: "2BB183AF5F22588179A53B0A98631FAD1A292118"}},
+	}
+
+	for _, c := range cases {
+		got, err := ParseRData(c.rrtype, c.wire)
+		if err != nil {
+			t.Errorf("ParseRData(%q, %q): %v", c.rrtype, c.wire, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("ParseRData(%q, %q): %#v, want: %#v", c.rrtype, c.wire, got, c.want)
+		}
+		if s := got.String(); s != c.wire {
+			t.Errorf("%T.String(): %q, want: %q", got, s, c.wire)
+		}
+		if got.RRType() != rrTypeName(c.rrtype) {
+			t.Errorf("%T.RRType(): %q", got, got.RRType())
+		}
+	}
+}
+
+func Test_ParseRData_Errors(t *testing.T) {
+	cases := []struct {
+		rrtype string
+		wire   string
+		want   string
+	}{
+		{"A", "2001:db8::1", "not an IPv4 address"},
+		{"A", "192.0.2", "ParseAddr"},
+		{"AAAA", "192.0.2.1", "not an IPv6 address"},
+		{"MX", "mail.example.com.", "not a 16-bit unsigned integer"},
+		{"MX", "70000 mail.example.com.", "not a 16-bit unsigned integer"},
+		{"MX", "10", "too few fields"},
+		{"CNAME", "a.example.com. b.example.com.", "unexpected"},
+		{"CAA", `0 issue "unterminated`, "unterminated quoted string"},
+		{"DS", "60485 5 1 XYZ", "not hex encoded"},
+		{"HINFO", "PC Linux", "unsupported record type"},
+	}
+	for _, c := range cases {
+		_, err := ParseRData(c.rrtype, c.wire)
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("ParseRData(%q, %q): %v, want error containing %q", c.rrtype, c.wire, err, c.want)
+		}
+	}
+}
+
+func Test_ParseRData_DSDigestWithSpaces(t *testing.T) {
+	got, err := ParseRData("DS", "60485 5 1 2BB183AF5F225881 79A53B0A98631FAD1A292118")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := got.(DSRData).Digest; d != "2BB183AF5F22588179A53B0A98631FAD1A292118" {
+		t.Errorf("Digest: %q", d)
+	}
+}
+
+func Test_RRSet_Records(t *testing.T) {
+	rr := RRSet{OwnerName: "example.com.", RRType: "MX (15)", TTL: 300, RData: []string{"10 mx1.example.com.", "20 mx2.example.com."}}
+
+	records, err := rr.Records()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RData{MXRData{10, "mx1.example.com."}, MXRData{20, "mx2.example.com."}}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("Records: %#v, want: %#v", records, want)
+	}
+	if rr.Type() != "MX" {
+		t.Errorf("Type: %q", rr.Type())
+	}
+
+	rr.RData = append(rr.RData, "bogus")
+	if _, err := rr.Records(); err == nil {
+		t.Errorf("Records succeeded with invalid rdata")
+	}
+}
+
+func Test_NewRRSet(t *testing.T) {
+	rr, err := NewRRSet("www.example.com.", 300,
+		ARData{Address: netip.MustParseAddr("192.0.2.1")},
+		ARData{Address: netip.MustParseAddr("192.0.2.2")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1", "192.0.2.2"}}
+	if !reflect.DeepEqual(rr, want) {
+		t.Errorf("NewRRSet: %+v, want: %+v", rr, want)
+	}
+
+	if _, err := NewRRSet("www.example.com.", 300, ARData{Address: netip.MustParseAddr("192.0.2.1")}, CNAMERData{Target: "x."}); err == nil {
+		t.Errorf("NewRRSet succeeded with mixed types")
+	}
+	if _, err := NewRRSet("www.example.com.", 300); err == nil {
+		t.Errorf("NewRRSet succeeded without records")
+	}
+}
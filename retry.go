@@ -0,0 +1,126 @@
+package udnssdk
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how Client.Do retries requests that fail with a
+// network error, a 429 Too Many Requests or a 5xx status. Token requests
+// rejected for bad credentials are not retried.
+//
+// Only idempotent requests (GET, HEAD, PUT, DELETE) are retried after a 5xx
+// or a network error: a POST or PATCH may have been applied by the server,
+// so it is only retried after a 429, or a network error occurring before the
+// request was sent.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 1 are treated as 1, disabling retries.
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry; each later retry doubles it.
+	MinBackoff time.Duration
+	// MaxBackoff caps the exponential backoff, and the delays asked by the
+	// server with Retry-After. Zero means no cap.
+	MaxBackoff time.Duration
+	// Jitter is the fraction of each backoff, between 0 and 1, that is randomized
+	// so concurrent clients do not retry in lockstep.
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used by NewClient
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.5,
+	}
+}
+
+// NoRetryPolicy returns a RetryPolicy which sends each request exactly once
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// attempts returns the effective number of attempts
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// retryable reports whether a request with method that produced r and err should be retried;
+// sent tells whether the request was written to the server
+func (p RetryPolicy) retryable(ctx context.Context, method string, r *http.Response, err error, sent bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		// rejected credentials will not be accepted on a retry
+		if errors.Is(err, ErrUnauthorized) {
+			return false
+		}
+		return idempotent(method) || !sent
+	}
+	if r.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return r.StatusCode >= 500 && idempotent(method)
+}
+
+// idempotent reports whether sending a request with method twice has the effect of sending it once
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns the wait before the retry following the given zero-based attempt
+func (p RetryPolicy) backoff(attempt int, r *http.Response) time.Duration {
+	if d, ok := retryAfter(r); ok {
+		if p.MaxBackoff > 0 && d > p.MaxBackoff {
+			d = p.MaxBackoff
+		}
+		return d
+	}
+
+	d := time.Duration(float64(p.MinBackoff) * math.Pow(2, float64(attempt)))
+	if p.MaxBackoff > 0 && (d > p.MaxBackoff || d < 0) {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 && d > 0 {
+		j := math.Min(p.Jitter, 1)
+		d -= time.Duration(rand.Float64() * j * float64(d))
+	}
+	return d
+}
+
+// retryAfter parses the Retry-After header of r, as either delay-seconds or an HTTP-date
+func retryAfter(r *http.Response) (time.Duration, bool) {
+	if r == nil {
+		return 0, false
+	}
+	v := r.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
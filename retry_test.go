@@ -0,0 +1,209 @@
+package udnssdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_RetryPolicy_RetriesServerErrors(t *testing.T) {
+	want := []Account{Account{AccountName: "terraform"}}
+	hits := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		switch hits {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		mess, _ := json.Marshal(AccountListDTO{Accounts: want})
+		fmt.Fprintln(w, string(mess))
+	}))
+	defer ts.Close()
+	c, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	c.RetryPolicy = RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+
+	accounts, _, err := c.Accounts.Select()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hits != 3 {
+		t.Errorf("hits: %d, want: %d", hits, 3)
+	}
+	if len(accounts) != 1 || accounts[0] != want[0] {
+		t.Errorf("accounts: %+v, want: %+v", accounts, want)
+	}
+}
+
+func Test_RetryPolicy_GivesUp(t *testing.T) {
+	hits := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+	c, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	c.RetryPolicy = RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+
+	res, err := c.Do("GET", "zones", nil, nil)
+
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if res == nil || res.StatusCode != http.StatusBadGateway {
+		t.Errorf("res: %+v, want status %d", res, http.StatusBadGateway)
+	}
+	if hits != 2 {
+		t.Errorf("hits: %d, want: %d", hits, 2)
+	}
+}
+
+func Test_RetryPolicy_DoesNotRetryClientErrors(t *testing.T) {
+	hits := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+	c, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	c.RetryPolicy = RetryPolicy{MaxAttempts: 5, MinBackoff: time.Millisecond}
+
+	c.Do("GET", "zones", nil, nil)
+
+	if hits != 1 {
+		t.Errorf("hits: %d, want: %d", hits, 1)
+	}
+}
+
+func Test_RetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 5 * time.Second},
+		{64, 5 * time.Second},
+	}
+	for _, c := range cases {
+		if d := p.backoff(c.attempt, nil); d != c.want {
+			t.Errorf("backoff(%d): %v, want: %v", c.attempt, d, c.want)
+		}
+	}
+
+	r := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if d := p.backoff(0, r); d != 3*time.Second {
+		t.Errorf("backoff with Retry-After: %v, want: %v", d, 3*time.Second)
+	}
+	r = &http.Response{Header: http.Header{"Retry-After": []string{"42"}}}
+	if d := p.backoff(0, r); d != 5*time.Second {
+		t.Errorf("backoff with Retry-After over MaxBackoff: %v, want: %v", d, 5*time.Second)
+	}
+}
+
+func Test_RetryPolicy_backoff_Jitter(t *testing.T) {
+	p := RetryPolicy{MinBackoff: time.Second, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		d := p.backoff(0, nil)
+		if d < 500*time.Millisecond || d > time.Second {
+			t.Fatalf("backoff: %v, want between %v and %v", d, 500*time.Millisecond, time.Second)
+		}
+	}
+}
+
+func Test_RetryPolicy_NonIdempotent(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	hits := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+	c, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	c.RetryPolicy = RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+
+	cases := []struct {
+		method string
+		status int
+		want   int
+	}{
+		{"POST", http.StatusServiceUnavailable, 1},
+		{"PATCH", http.StatusBadGateway, 1},
+		{"POST", http.StatusTooManyRequests, 3},
+		{"PUT", http.StatusServiceUnavailable, 3},
+		{"DELETE", http.StatusServiceUnavailable, 3},
+	}
+	for _, tc := range cases {
+		hits, status = 0, tc.status
+		c.Do(tc.method, "zones", nil, nil)
+		if hits != tc.want {
+			t.Errorf("%s %d: hits: %d, want: %d", tc.method, tc.status, hits, tc.want)
+		}
+	}
+}
+
+// failingTransport fails every request, before sending it unless it is hung up on by hangUp
+type failingTransport struct {
+	n      int
+	hangUp *httptest.Server
+}
+
+func (t *failingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.n++
+	if t.hangUp != nil {
+		u := *r.URL
+		u.Host = t.hangUp.Listener.Addr().String()
+		r2 := r.Clone(r.Context())
+		r2.URL = &u
+		r2.Host = ""
+		return http.DefaultTransport.RoundTrip(r2)
+	}
+	return nil, fmt.Errorf("dial: connection refused")
+}
+
+func Test_RetryPolicy_NetworkErrors(t *testing.T) {
+	hangUp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, _ := w.(http.Hijacker).Hijack()
+		conn.Close()
+	}))
+	defer hangUp.Close()
+
+	cases := []struct {
+		method string
+		sent   bool
+		want   int
+	}{
+		{"POST", false, 3},
+		{"POST", true, 1},
+		{"GET", true, 3},
+	}
+	for _, tc := range cases {
+		rt := &failingTransport{}
+		if tc.sent {
+			rt.hangUp = hangUp
+		}
+		c, _ := newStubClient(testUsername, testPassword, "http://example.invalid/", "", "")
+		c.HTTPClient = &http.Client{Transport: rt}
+		c.RetryPolicy = RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+
+		if _, err := c.Do(tc.method, "zones", nil, nil); err == nil {
+			t.Errorf("%s: expected an error", tc.method)
+		}
+		if rt.n != tc.want {
+			t.Errorf("%s sent %v: attempts: %d, want: %d", tc.method, tc.sent, rt.n, tc.want)
+		}
+	}
+}
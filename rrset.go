@@ -1,13 +1,10 @@
 package udnssdk
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
-	"time"
-
-	"github.com/fatih/structs"
-	"github.com/mitchellh/mapstructure"
 )
 
 // RRSetsService provides access to RRSet resources
@@ -15,226 +12,33 @@ type RRSetsService struct {
 	client *Client
 }
 
-// Here is the big 'Profile' mess that should get refactored to a more managable place
-
-// ProfileSchema are the schema URIs for RRSet Profiles
-type ProfileSchema string
-
-const (
-	// DirPoolSchema is the schema URI for a Directional pool profile
-	DirPoolSchema ProfileSchema = "http://schemas.ultradns.com/DirPool.jsonschema"
-	// RDPoolSchema is the schema URI for a Resource Distribution pool profile
-	RDPoolSchema = "http://schemas.ultradns.com/RDPool.jsonschema"
-	// SBPoolSchema is the schema URI for a SiteBacker pool profile
-	SBPoolSchema = "http://schemas.ultradns.com/SBPool.jsonschema"
-	// TCPoolSchema is the schema URI for a Traffic Controller pool profile
-	TCPoolSchema = "http://schemas.ultradns.com/TCPool.jsonschema"
-)
-
-// RawProfile represents the naive interface to an RRSet Profile
-type RawProfile map[string]interface{}
-
-// Context extracts the schema context from a RawProfile
-func (rp RawProfile) Context() ProfileSchema {
-	return ProfileSchema(rp["@context"].(string))
-}
-
-// GetProfileObject extracts the full Profile by its schema type
-func (rp RawProfile) GetProfileObject() (interface{}, error) {
-	c := rp.Context()
-	switch c {
-	case DirPoolSchema:
-		return rp.DirPoolProfile()
-	case RDPoolSchema:
-		return rp.RDPoolProfile()
-	case SBPoolSchema:
-		return rp.SBPoolProfile()
-	case TCPoolSchema:
-		return rp.TCPoolProfile()
-	default:
-		return nil, fmt.Errorf("fallthrough on GetProfileObject type %s", c)
-	}
-}
-
-// decode takes a RawProfile and uses reflection to convert it into the
-// given Go native structure. val must be a pointer to a struct.
-// This is identical to mapstructure.Decode, but uses the `json:` tag instead of `mapstructure:`
-func decodeProfile(m interface{}, rawVal interface{}) error {
-	config := &mapstructure.DecoderConfig{
-		Metadata:         nil,
-		TagName:          "json",
-		Result:           rawVal,
-		ErrorUnused:      true,
-		WeaklyTypedInput: true,
+// RRSet wraps an RRSet resource
+type RRSet struct {
+	OwnerName string   `json:"ownerName"`
+	RRType    string   `json:"rrtype"`
+	TTL       int      `json:"ttl"`
+	RData     []string `json:"rdata"`
+	// Profile is the pool profile of the RRSet, decoded as the type of its @context
+	Profile Profile `json:"profile,omitempty"`
+}
+
+// UnmarshalJSON decodes an RRSet, with its profile as the Profile type of its @context
+func (r *RRSet) UnmarshalJSON(data []byte) error {
+	type rrset RRSet
+	var v struct {
+		rrset
+		Profile json.RawMessage `json:"profile"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
 	}
-
-	decoder, err := mapstructure.NewDecoder(config)
+	*r = RRSet(v.rrset)
+	p, err := ParseProfile(v.Profile)
 	if err != nil {
 		return err
 	}
-
-	return decoder.Decode(m)
-}
-
-// DirPoolProfile extracts the full Profile as a DirPoolProfile or returns an error
-func (rp RawProfile) DirPoolProfile() (DirPoolProfile, error) {
-	var result DirPoolProfile
-	c := rp.Context()
-	if c != DirPoolSchema {
-		return result, fmt.Errorf("incorrect JSON-LD @context for DirPoolProfile %s", c)
-	}
-	err := decodeProfile(rp, &result)
-	return result, err
-}
-
-// RDPoolProfile extracts the full Profile as a RDPoolProfile or returns an error
-func (rp RawProfile) RDPoolProfile() (RDPoolProfile, error) {
-	var result RDPoolProfile
-	c := rp.Context()
-	if c != RDPoolSchema {
-		return result, fmt.Errorf("incorrect JSON-LD @context for RDPoolProfile %s", c)
-	}
-	err := decodeProfile(rp, &result)
-	return result, err
-}
-
-// SBPoolProfile extracts the full Profile as a SBPoolProfile or returns an error
-func (rp RawProfile) SBPoolProfile() (SBPoolProfile, error) {
-	var result SBPoolProfile
-	c := rp.Context()
-	if c != SBPoolSchema {
-		return result, fmt.Errorf("incorrect JSON-LD @context for SBPoolProfile %s", c)
-	}
-	err := decodeProfile(rp, &result)
-	return result, err
-}
-
-// TCPoolProfile extracts the full Profile as a TCPoolProfile or returns an error
-func (rp RawProfile) TCPoolProfile() (TCPoolProfile, error) {
-	var result TCPoolProfile
-	c := rp.Context()
-	if c != TCPoolSchema {
-		return result, fmt.Errorf("incorrect JSON-LD @context for TCPoolProfile %s", c)
-	}
-	err := decodeProfile(rp, &result)
-	return result, err
-}
-
-// encodeProfile takes a struct and converts to a RawProfile
-func encodeProfile(rawVal interface{}) RawProfile {
-	s := structs.New(rawVal)
-	s.TagName = "json"
-	return s.Map()
-}
-
-// RawProfile converts to a naive RawProfile
-func (p DirPoolProfile) RawProfile() RawProfile {
-	return encodeProfile(p)
-}
-
-// RawProfile converts to a naive RawProfile
-func (p RDPoolProfile) RawProfile() RawProfile {
-	return encodeProfile(p)
-}
-
-// RawProfile converts to a naive RawProfile
-func (p SBPoolProfile) RawProfile() RawProfile {
-	return encodeProfile(p)
-}
-
-// RawProfile converts to a naive RawProfile
-func (p TCPoolProfile) RawProfile() RawProfile {
-	return encodeProfile(p)
-}
-
-// DirPoolProfile wraps a Profile for a Directional Pool
-type DirPoolProfile struct {
-	Context         ProfileSchema `json:"@context"`
-	Description     string        `json:"description"`
-	ConflictResolve string        `json:"conflictResolve,omitempty"`
-	RDataInfo       []DPRDataInfo `json:"rdataInfo"`
-	NoResponse      DPRDataInfo   `json:"noResponse,omitempty"`
-}
-
-// DPRDataInfo wraps the rdataInfo object of a DirPoolProfile response
-type DPRDataInfo struct {
-	AllNonConfigured bool     `json:"allNonConfigured,omitempty" terraform:"all_non_configured"`
-	IPInfo           *IPInfo  `json:"ipInfo,omitempty" terraform:"ip_info"`
-	GeoInfo          *GeoInfo `json:"geoInfo,omitempty" terraform:"geo_info"`
-	Type             string   `json:"type,omitempty" terraform:"type"` // not mentioned in REST API doc
-}
-
-// IPInfo wraps the ipInfo object of a DPRDataInfo
-type IPInfo struct {
-	Name           string      `json:"name" terraform:"name"`
-	IsAccountLevel bool        `json:"isAccountLevel,omitempty" terraform:"is_account_level"`
-	Ips            []IPAddrDTO `json:"ips,omitempty" terraform:"-"`
-}
-
-// GeoInfo wraps the geoInfo object of a DPRDataInfo
-type GeoInfo struct {
-	Name           string   `json:"name" terraform:"name"`
-	IsAccountLevel bool     `json:"isAccountLevel,omitempty" terraform:"is_account_level"`
-	Codes          []string `json:"codes,omitempty" terraform:"-"`
-}
-
-// RDPoolProfile wraps a Profile for a Resource Distribution pool
-type RDPoolProfile struct {
-	Context     ProfileSchema `json:"@context"`
-	Order       string        `json:"order"`
-	Description string        `json:"description"`
-}
-
-// SBPoolProfile wraps a Profile for a SiteBacker pool
-type SBPoolProfile struct {
-	Context       ProfileSchema  `json:"@context"`
-	Description   string         `json:"description"`
-	RunProbes     bool           `json:"runProbes"`
-	ActOnProbes   bool           `json:"actOnProbes"`
-	Order         string         `json:"order,omitempty"`
-	MaxActive     int            `json:"maxActive,omitempty"`
-	MaxServed     int            `json:"maxServed,omitempty"`
-	RDataInfo     []SBRDataInfo  `json:"rdataInfo"`
-	BackupRecords []BackupRecord `json:"backupRecords"`
-}
-
-// SBRDataInfo wraps the rdataInfo object of a SBPoolProfile
-type SBRDataInfo struct {
-	State            string `json:"state"`
-	RunProbes        bool   `json:"runProbes"`
-	Priority         int    `json:"priority"`
-	FailoverDelay    int    `json:"failoverDelay,omitempty"`
-	Threshold        int    `json:"threshold"`
-	Weight           int    `json:"weight"`
-	AvailableToServe bool   `json:"availableToServe,omitempty"`
-}
-
-// BackupRecord wraps the backupRecord objects of an SBPoolProfile response
-type BackupRecord struct {
-	RData            string `json:"rdata,omitempty"`
-	FailoverDelay    int    `json:"failoverDelay,omitempty"`
-	AvailableToServe bool   `json:"availableToServe,omitempty"`
-}
-
-// TCPoolProfile wraps a Profile for a Traffic Controller pool
-type TCPoolProfile struct {
-	Context      ProfileSchema `json:"@context"`
-	Description  string        `json:"description"`
-	RunProbes    bool          `json:"runProbes"`
-	ActOnProbes  bool          `json:"actOnProbes"`
-	MaxToLB      int           `json:"maxToLB,omitempty"`
-	RDataInfo    []SBRDataInfo `json:"rdataInfo"`
-	BackupRecord *BackupRecord `json:"backupRecord,omitempty"`
-	Status       string        `json:"status,omitempty"`
-}
-
-// RRSet wraps an RRSet resource
-type RRSet struct {
-	OwnerName string     `json:"ownerName"`
-	RRType    string     `json:"rrtype"`
-	TTL       int        `json:"ttl"`
-	RData     []string   `json:"rdata"`
-	Profile   RawProfile `json:"profile,omitempty"`
+	r.Profile = p
+	return nil
 }
 
 // RRSetListDTO wraps a list of RRSet resources
@@ -273,6 +77,14 @@ func (k RRSetKey) QueryURI(offset int) string {
 	return fmt.Sprintf("%s?offset=%d", k.URI(), offset)
 }
 
+// queryURI generates the URI for the RRSets of k matching q, starting at offset
+func (k RRSetKey) queryURI(q Query, offset int) string {
+	if k.Type == "" {
+		k.Type = "ANY"
+	}
+	return q.URI(k.URI(), offset)
+}
+
 // AlertsURI generates the URI for an RRSet
 func (k RRSetKey) AlertsURI() string {
 	return fmt.Sprintf("%s/alerts", k.URI())
@@ -336,45 +148,58 @@ func (k RRSetKey) ProbesQueryURI(query string) string {
 
 // Select will list the zone rrsets, paginating through all available results
 func (s *RRSetsService) Select(k RRSetKey) ([]RRSet, error) {
-	// TODO: Sane Configuration for timeouts / retries
-	maxerrs := 5
-	waittime := 5 * time.Second
-
-	rrsets := []RRSet{}
-	errcnt := 0
-	offset := 0
-
-	for {
-		reqRrsets, ri, res, err := s.SelectWithOffset(k, offset)
-		if err != nil {
-			if res != nil && res.StatusCode >= 500 {
-				errcnt = errcnt + 1
-				if errcnt < maxerrs {
-					time.Sleep(waittime)
-					continue
-				}
-			}
-			return rrsets, err
-		}
+	return s.SelectContext(context.Background(), k)
+}
 
-		log.Printf("ResultInfo: %+v\n", ri)
-		for _, rrset := range reqRrsets {
-			rrsets = append(rrsets, rrset)
-		}
-		if ri.ReturnedCount+ri.Offset >= ri.TotalCount {
-			return rrsets, nil
-		}
-		offset = ri.ReturnedCount + ri.Offset
-		continue
-	}
+// SelectContext is Select with a context.Context
+func (s *RRSetsService) SelectContext(ctx context.Context, k RRSetKey) ([]RRSet, error) {
+	return NewPager(s.client, func(ctx context.Context, _ QueryInfo, offset int) ([]RRSet, ResultInfo, *http.Response, error) {
+		return s.SelectWithOffsetContext(ctx, k, offset)
+	}).Collect(ctx)
 }
 
 // SelectWithOffset requests zone rrsets by RRSetKey & optional offset
 func (s *RRSetsService) SelectWithOffset(k RRSetKey, offset int) ([]RRSet, ResultInfo, *http.Response, error) {
+	return s.SelectWithOffsetContext(context.Background(), k, offset)
+}
+
+// SelectWithOffsetContext is SelectWithOffset with a context.Context
+func (s *RRSetsService) SelectWithOffsetContext(ctx context.Context, k RRSetKey, offset int) ([]RRSet, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, k.QueryURI(offset))
+}
+
+// SelectQuery will list the zone rrsets matching q, paginating through all available results
+func (s *RRSetsService) SelectQuery(k RRSetKey, q Query) ([]RRSet, error) {
+	return s.SelectQueryContext(context.Background(), k, q)
+}
+
+// SelectQueryContext is SelectQuery with a context.Context
+func (s *RRSetsService) SelectQueryContext(ctx context.Context, k RRSetKey, q Query) ([]RRSet, error) {
+	return s.Pager(k, q).Collect(ctx)
+}
+
+// SelectQueryWithOffset requests zone rrsets by RRSetKey matching q, starting at offset
+func (s *RRSetsService) SelectQueryWithOffset(k RRSetKey, q Query, offset int) ([]RRSet, ResultInfo, *http.Response, error) {
+	return s.SelectQueryWithOffsetContext(context.Background(), k, q, offset)
+}
+
+// SelectQueryWithOffsetContext is SelectQueryWithOffset with a context.Context
+func (s *RRSetsService) SelectQueryWithOffsetContext(ctx context.Context, k RRSetKey, q Query, offset int) ([]RRSet, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, k.queryURI(q, offset))
+}
+
+// Pager returns a Pager over the RRSets of k matching q
+func (s *RRSetsService) Pager(k RRSetKey, q Query) *Pager[RRSet] {
+	return newQueryPager(s.client, q, func(ctx context.Context, q Query, offset int) ([]RRSet, ResultInfo, *http.Response, error) {
+		return s.SelectQueryWithOffsetContext(ctx, k, q, offset)
+	})
+}
+
+// selectURI requests a page of rrsets from uri
+func (s *RRSetsService) selectURI(ctx context.Context, uri string) ([]RRSet, ResultInfo, *http.Response, error) {
 	var rrsld RRSetListDTO
 
-	uri := k.QueryURI(offset)
-	res, err := s.client.get(uri, &rrsld)
+	res, err := s.client.get(ctx, uri, &rrsld)
 
 	rrsets := []RRSet{}
 	for _, rrset := range rrsld.Rrsets {
@@ -385,17 +210,42 @@ func (s *RRSetsService) SelectWithOffset(k RRSetKey, offset int) ([]RRSet, Resul
 
 // Create creates an rrset with val
 func (s *RRSetsService) Create(k RRSetKey, rrset RRSet) (*http.Response, error) {
+	return s.CreateContext(context.Background(), k, rrset)
+}
+
+// CreateContext is Create with a context.Context
+func (s *RRSetsService) CreateContext(ctx context.Context, k RRSetKey, rrset RRSet) (*http.Response, error) {
+	if s.client.ValidateRRSets {
+		if err := k.validate(rrset); err != nil {
+			return nil, err
+		}
+	}
 	var ignored interface{}
-	return s.client.post(k.URI(), rrset, &ignored)
+	return s.client.post(ctx, k.URI(), rrset, &ignored)
 }
 
 // Update updates a RRSet with the provided val
 func (s *RRSetsService) Update(k RRSetKey, val RRSet) (*http.Response, error) {
+	return s.UpdateContext(context.Background(), k, val)
+}
+
+// UpdateContext is Update with a context.Context
+func (s *RRSetsService) UpdateContext(ctx context.Context, k RRSetKey, val RRSet) (*http.Response, error) {
+	if s.client.ValidateRRSets {
+		if err := k.validate(val); err != nil {
+			return nil, err
+		}
+	}
 	var ignored interface{}
-	return s.client.put(k.URI(), val, &ignored)
+	return s.client.put(ctx, k.URI(), val, &ignored)
 }
 
 // Delete deletes an RRSet
 func (s *RRSetsService) Delete(k RRSetKey) (*http.Response, error) {
-	return s.client.delete(k.URI(), nil)
+	return s.DeleteContext(context.Background(), k)
+}
+
+// DeleteContext is Delete with a context.Context
+func (s *RRSetsService) DeleteContext(ctx context.Context, k RRSetKey) (*http.Response, error) {
+	return s.client.delete(ctx, k.URI(), nil)
 }
@@ -66,15 +66,14 @@ func Test_RRSets_Select(t *testing.T) {
 	t.Logf("Checking for profiles...\n")
 	for _, rr := range rrsets {
 		if rr.Profile != nil {
-			typ := rr.Profile.Context()
+			typ := rr.Profile.Schema()
 			if typ == "" {
 				t.Fatalf("Could not get type for profile %+v\n", rr.Profile)
 			}
-			t.Logf("Found Profile %s for %s\n", rr.Profile.Context(), rr.OwnerName)
+			t.Logf("Found Profile %s for %s\n", rr.Profile.Schema(), rr.OwnerName)
 			st, er := json.Marshal(rr.Profile)
 			t.Logf("Marshal the profile to JSON: %s / %+v", string(st), er)
-			p, _ := rr.Profile.GetProfileObject()
-			t.Logf("Check the Magic Profile: %+v\n", p)
+			t.Logf("Check the Magic Profile: %+v\n", rr.Profile)
 		}
 	}
 }
@@ -227,8 +226,7 @@ func Test_RRSets_SelectMid(t *testing.T) {
 	if rrsets[0].RData[0] != testIP2 {
 		t.Fatalf("RData[0]\"%s\" != testIP2\"%s\"", rrsets[0].RData[0], testIP2)
 	}
-	p, _ := rrsets[0].Profile.GetProfileObject()
-	t.Logf("Check the Magic Profile: %+v\n", p)
+	t.Logf("Check the Magic Profile: %+v\n", rrsets[0].Profile)
 }
 
 func Test_RRSet_Delete(t *testing.T) {
@@ -316,10 +314,9 @@ func Test_RRSet_SelectPost(t *testing.T) {
 func Test_DirPoolProfile_RawProfile(t *testing.T) {
 	p := DirPoolProfile{}
 	expected := RawProfile{
-		"@context":    ProfileSchema(""),
+		"@context":    string(DirPoolSchema),
 		"description": "",
-		// "rdataInfo":   []DPRDataInfo(nil),
-		"rdataInfo": []interface{}{},
+		"rdataInfo":   nil,
 	}
 
 	actual := p.RawProfile()
@@ -342,7 +339,7 @@ func Test_DirPoolProfile_RawProfile(t *testing.T) {
 func Test_RDPoolProfile_RawProfile(t *testing.T) {
 	p := RDPoolProfile{}
 	expected := RawProfile{
-		"@context":    ProfileSchema(""),
+		"@context":    string(RDPoolSchema),
 		"description": "",
 		"order":       "",
 	}
@@ -367,14 +364,12 @@ func Test_RDPoolProfile_RawProfile(t *testing.T) {
 func Test_SBPoolProfile_RawProfile(t *testing.T) {
 	p := SBPoolProfile{}
 	expected := RawProfile{
-		"@context":    ProfileSchema(""),
-		"description": "",
-		"actOnProbes": false,
-		// "rdataInfo":   []SBRDataInfo(nil),
-		"rdataInfo": []interface{}{},
-		"runProbes": false,
-		// "backupRecords": []BackupRecord(nil),
-		"backupRecords": []interface{}{},
+		"@context":      string(SBPoolSchema),
+		"description":   "",
+		"actOnProbes":   false,
+		"rdataInfo":     nil,
+		"runProbes":     false,
+		"backupRecords": nil,
 	}
 
 	actual := p.RawProfile()
@@ -423,12 +418,11 @@ func Test_RawProfile_TCPoolProfile(t *testing.T) {
 func Test_TCPoolProfile_RawProfile(t *testing.T) {
 	input := TCPoolProfile{}
 	expected := RawProfile{
-		"@context":    ProfileSchema(""),
+		"@context":    string(TCPoolSchema),
 		"description": "",
 		"actOnProbes": false,
-		// "rdataInfo":   []SBRDataInfo(nil),
-		"rdataInfo": []interface{}{},
-		"runProbes": false,
+		"rdataInfo":   nil,
+		"runProbes":   false,
 	}
 
 	actual := input.RawProfile()
@@ -0,0 +1,220 @@
+package udnssdk
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Here lie the states of the records of SiteBacker and Traffic Controller pools
+const (
+	// SBStateNormal records are served according to their probes
+	SBStateNormal = "NORMAL"
+	// SBStateActive records are always served
+	SBStateActive = "ACTIVE"
+	// SBStateInactive records are never served
+	SBStateInactive = "INACTIVE"
+)
+
+// SBProbeStep is the outcome of the probes of a pool at a point in time
+type SBProbeStep struct {
+	// At is the time of the outcome since the start of the simulation
+	At time.Duration
+	// Failing is the number of failing probes of each record, by rdata; records it omits pass all theirs.
+	// A record fails once its failing probes reach its threshold.
+	Failing map[string]int
+}
+
+// SBAnswer is the state of a pool after an SBProbeStep
+type SBAnswer struct {
+	At time.Duration
+	// Served lists the records served in the order they are answered: by priority for FIXED pools,
+	// rotated at each step for ROUND_ROBIN ones and shuffled for RANDOM ones
+	Served []string
+	// Backup is set when the records served are backup records
+	Backup bool
+	// Failed lists the pool records taken out of service, in the order of the RRSet
+	Failed []string
+	// Weights are the shares of the traffic of each served record of a Traffic Controller pool, by rdata
+	Weights map[string]float64
+}
+
+// SBPoolSimulator predicts, offline, the records a SiteBacker or Traffic Controller pool serves as its probes fail and recover.
+//
+// A record failing its probes is taken out of service once it has failed for its failoverDelay, and is back as
+// soon as it passes. The pool serves the MaxActive available records of lowest priority, in its Order and
+// MaxServed of them at most; a Traffic Controller pool load balances between MaxToLB of them, by weight.
+// Once no record is available, the backup records whose failoverDelay has passed are served; with none,
+// the pool answers nothing.
+type SBPoolSimulator struct {
+	RData     []string
+	RDataInfo []SBRDataInfo
+	// RunProbes and ActOnProbes of the profile: without both, probes take no record out of service
+	RunProbes   bool
+	ActOnProbes bool
+	// MaxActive is the number of records served at once, the maxActive of SiteBacker pools (0 is the API's default, 1)
+	// and the maxToLB of Traffic Controller pools (0 is all of them)
+	MaxActive int
+	// MaxServed is the number of records in an answer, 0 for all the active ones
+	MaxServed int
+	// Order is the order of SiteBacker pools: OrderFixed (or ""), OrderRandom or OrderRoundRobin
+	Order string
+	// Rand shuffles the records of RANDOM pools; nil uses the default source of math/rand
+	Rand          *rand.Rand
+	BackupRecords []BackupRecord
+	// Weighted is set for Traffic Controller pools, whose answers carry Weights
+	Weighted bool
+}
+
+// NewSBPoolSimulator returns an SBPoolSimulator for a SiteBacker or Traffic Controller pool RRSet
+func NewSBPoolSimulator(rr RRSet) (*SBPoolSimulator, error) {
+	p := rr.Profile
+	if rp, ok := p.(RawProfile); ok {
+		var err error
+		if p, err = rp.Profile(); err != nil {
+			return nil, err
+		}
+	}
+	s := &SBPoolSimulator{RData: rr.RData}
+	switch v := p.(type) {
+	case SBPoolProfile:
+		s.RDataInfo, s.RunProbes, s.ActOnProbes = v.RDataInfo, v.RunProbes, v.ActOnProbes
+		s.MaxActive, s.MaxServed, s.BackupRecords, s.Order = v.MaxActive, v.MaxServed, v.BackupRecords, v.Order
+		if s.MaxActive == 0 {
+			s.MaxActive = 1
+		}
+	case TCPoolProfile:
+		s.RDataInfo, s.RunProbes, s.ActOnProbes = v.RDataInfo, v.RunProbes, v.ActOnProbes
+		s.MaxActive, s.Weighted = v.MaxToLB, true
+		if v.BackupRecord != nil {
+			s.BackupRecords = []BackupRecord{*v.BackupRecord}
+		}
+	default:
+		return nil, fmt.Errorf("%s %s is not a SiteBacker or Traffic Controller pool", rr.RRType, rr.OwnerName)
+	}
+	if len(s.RDataInfo) != len(s.RData) {
+		return nil, fmt.Errorf("profile has %d rdataInfo for %d rdata", len(s.RDataInfo), len(s.RData))
+	}
+	if err := s.checkOrder(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// checkOrder returns an error if the Order of s is not one the simulator knows
+func (s *SBPoolSimulator) checkOrder() error {
+	switch s.Order {
+	case "", OrderFixed, OrderRandom, OrderRoundRobin:
+		return nil
+	}
+	return fmt.Errorf("order %q is not one of %s, %s, %s", s.Order, OrderFixed, OrderRandom, OrderRoundRobin)
+}
+
+// Run returns the answer of the pool after each step; steps must be in time order
+func (s *SBPoolSimulator) Run(steps []SBProbeStep) ([]SBAnswer, error) {
+	if err := s.checkOrder(); err != nil {
+		return nil, err
+	}
+	failingSince := map[int]time.Duration{}
+	downSince := time.Duration(-1)
+	turn := 0
+	answers := make([]SBAnswer, 0, len(steps))
+	for i, step := range steps {
+		if i > 0 && step.At < steps[i-1].At {
+			return nil, fmt.Errorf("step %d at %s is before step %d at %s", i, step.At, i-1, steps[i-1].At)
+		}
+
+		a := SBAnswer{At: step.At}
+		available := []int{}
+		for j, info := range s.RDataInfo {
+			failing := s.failing(info, step.Failing[s.RData[j]])
+			since, ok := failingSince[j]
+			if !failing {
+				delete(failingSince, j)
+			} else if !ok {
+				failingSince[j], since = step.At, step.At
+			}
+			switch {
+			case info.State == SBStateInactive:
+			case info.State == SBStateActive:
+				available = append(available, j)
+			case failing && step.At-since >= minutes(info.FailoverDelay):
+				a.Failed = append(a.Failed, s.RData[j])
+			default:
+				available = append(available, j)
+			}
+		}
+
+		if len(available) == 0 {
+			if downSince < 0 {
+				downSince = step.At
+			}
+			for _, b := range s.BackupRecords {
+				if step.At-downSince >= minutes(b.FailoverDelay) {
+					a.Served = append(a.Served, b.RData)
+				}
+			}
+			a.Backup = len(a.Served) != 0
+			answers = append(answers, a)
+			continue
+		}
+		downSince = -1
+
+		sort.SliceStable(available, func(x, y int) bool {
+			return s.RDataInfo[available[x]].Priority < s.RDataInfo[available[y]].Priority
+		})
+		if s.MaxActive > 0 && len(available) > s.MaxActive {
+			available = available[:s.MaxActive]
+		}
+		switch s.Order {
+		case OrderRoundRobin:
+			n := turn % len(available)
+			available = append(append([]int{}, available[n:]...), available[:n]...)
+			turn++
+		case OrderRandom:
+			shuffle := rand.Shuffle
+			if s.Rand != nil {
+				shuffle = s.Rand.Shuffle
+			}
+			shuffle(len(available), func(x, y int) { available[x], available[y] = available[y], available[x] })
+		}
+		if s.MaxServed > 0 && len(available) > s.MaxServed {
+			available = available[:s.MaxServed]
+		}
+		total := 0
+		for _, j := range available {
+			a.Served = append(a.Served, s.RData[j])
+			total += s.RDataInfo[j].Weight
+		}
+		if s.Weighted {
+			a.Weights = map[string]float64{}
+			for _, j := range available {
+				if total == 0 {
+					a.Weights[s.RData[j]] = 1 / float64(len(available))
+				} else {
+					a.Weights[s.RData[j]] = float64(s.RDataInfo[j].Weight) / float64(total)
+				}
+			}
+		}
+		answers = append(answers, a)
+	}
+	return answers, nil
+}
+
+// failing reports whether a record with failed failing probes is failing, for the pool to act on
+func (s *SBPoolSimulator) failing(info SBRDataInfo, failed int) bool {
+	if !s.RunProbes || !s.ActOnProbes || !info.RunProbes {
+		return false
+	}
+	threshold := info.Threshold
+	if threshold < 1 {
+		threshold = 1
+	}
+	return failed >= threshold
+}
+
+// minutes converts the failoverDelay of the API, in minutes
+func minutes(n int) time.Duration {
+	return time.Duration(n) * time.Minute
+}
@@ -0,0 +1,200 @@
+package udnssdk
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_SBPoolSimulator_Run(t *testing.T) {
+	rr := RRSet{
+		OwnerName: "pool",
+		RRType:    "A",
+		RData:     []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"},
+		Profile: SBPoolProfile{
+			RunProbes:   true,
+			ActOnProbes: true,
+			MaxActive:   1,
+			RDataInfo: []SBRDataInfo{
+				{State: SBStateNormal, RunProbes: true, Priority: 1, Threshold: 2, FailoverDelay: 5},
+				{State: SBStateNormal, RunProbes: true, Priority: 2, Threshold: 1},
+				{State: SBStateInactive, RunProbes: true, Priority: 3, Threshold: 1},
+			},
+			BackupRecords: []BackupRecord{{RData: "198.51.100.1", FailoverDelay: 2}},
+		},
+	}
+	s, err := NewSBPoolSimulator(rr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []SBProbeStep{
+		{At: 0},
+		{At: time.Minute, Failing: map[string]int{"192.0.2.1": 1}},
+		{At: 2 * time.Minute, Failing: map[string]int{"192.0.2.1": 2}},
+		{At: 7 * time.Minute, Failing: map[string]int{"192.0.2.1": 2}},
+		{At: 8 * time.Minute, Failing: map[string]int{"192.0.2.1": 3, "192.0.2.2": 1}},
+		{At: 10 * time.Minute, Failing: map[string]int{"192.0.2.1": 3, "192.0.2.2": 1}},
+		{At: 11 * time.Minute, Failing: map[string]int{"192.0.2.2": 1}},
+	}
+	want := []SBAnswer{
+		{At: 0, Served: []string{"192.0.2.1"}},
+		// below the threshold
+		{At: time.Minute, Served: []string{"192.0.2.1"}},
+		// failing, but within its failoverDelay
+		{At: 2 * time.Minute, Served: []string{"192.0.2.1"}},
+		{At: 7 * time.Minute, Served: []string{"192.0.2.2"}, Failed: []string{"192.0.2.1"}},
+		// the backup waits for its own failoverDelay
+		{At: 8 * time.Minute, Failed: []string{"192.0.2.1", "192.0.2.2"}},
+		{At: 10 * time.Minute, Served: []string{"198.51.100.1"}, Backup: true, Failed: []string{"192.0.2.1", "192.0.2.2"}},
+		{At: 11 * time.Minute, Served: []string{"192.0.2.1"}, Failed: []string{"192.0.2.2"}},
+	}
+	got, err := s.Run(steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("step %d: %+v, want: %+v", i, got[i], want[i])
+		}
+	}
+
+	s.ActOnProbes = false
+	got, _ = s.Run(steps[4:5])
+	if !reflect.DeepEqual(got[0].Served, []string{"192.0.2.1"}) {
+		t.Errorf("actOnProbes false: %+v", got[0])
+	}
+
+	if _, err := s.Run([]SBProbeStep{{At: time.Minute}, {At: 0}}); err == nil {
+		t.Error("Run: no error for steps out of order")
+	}
+}
+
+func Test_SBPoolSimulator_TCPool(t *testing.T) {
+	rr := RRSet{
+		RData: []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"},
+		Profile: RawProfile{
+			"@context":    string(TCPoolSchema),
+			"runProbes":   true,
+			"actOnProbes": true,
+			"maxToLB":     2,
+			"rdataInfo": []interface{}{
+				map[string]interface{}{"state": "NORMAL", "runProbes": true, "priority": 1, "threshold": 1, "weight": 60},
+				map[string]interface{}{"state": "ACTIVE", "runProbes": true, "priority": 2, "threshold": 1, "weight": 20},
+				map[string]interface{}{"state": "NORMAL", "runProbes": true, "priority": 3, "threshold": 1, "weight": 20},
+			},
+			"backupRecord": map[string]interface{}{"rdata": "198.51.100.1"},
+		},
+	}
+	s, err := NewSBPoolSimulator(rr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Run([]SBProbeStep{
+		{At: 0},
+		{At: time.Minute, Failing: map[string]int{"192.0.2.1": 1, "192.0.2.2": 3}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SBAnswer{
+		{At: 0, Served: []string{"192.0.2.1", "192.0.2.2"}, Weights: map[string]float64{"192.0.2.1": 0.75, "192.0.2.2": 0.25}},
+		// ACTIVE records are served whatever their probes
+		{At: time.Minute, Served: []string{"192.0.2.2", "192.0.2.3"}, Failed: []string{"192.0.2.1"}, Weights: map[string]float64{"192.0.2.2": 0.5, "192.0.2.3": 0.5}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Run: %+v, want: %+v", got, want)
+	}
+	if !reflect.DeepEqual(s.BackupRecords, []BackupRecord{{RData: "198.51.100.1"}}) {
+		t.Errorf("BackupRecords: %+v", s.BackupRecords)
+	}
+}
+
+func Test_SBPoolSimulator_Order(t *testing.T) {
+	rr := RRSet{
+		OwnerName: "pool",
+		RRType:    "A",
+		RData:     []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"},
+		Profile: SBPoolProfile{
+			MaxActive: 3,
+			RDataInfo: []SBRDataInfo{
+				{State: SBStateNormal, Priority: 1},
+				{State: SBStateNormal, Priority: 2},
+				{State: SBStateNormal, Priority: 3},
+			},
+		},
+	}
+	steps := []SBProbeStep{{At: 0}, {At: time.Minute}, {At: 2 * time.Minute}, {At: 3 * time.Minute}}
+	run := func(order string, maxServed int) []string {
+		p := rr.Profile.(SBPoolProfile)
+		p.Order, p.MaxServed = order, maxServed
+		rr := rr
+		rr.Profile = p
+		s, err := NewSBPoolSimulator(rr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Rand = rand.New(rand.NewSource(1))
+		answers, err := s.Run(steps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		served := []string{}
+		for _, a := range answers {
+			served = append(served, fmt.Sprint(a.Served))
+		}
+		return served
+	}
+
+	cases := []struct {
+		order     string
+		maxServed int
+		want      string
+	}{
+		{"", 0, "[[192.0.2.1 192.0.2.2 192.0.2.3] [192.0.2.1 192.0.2.2 192.0.2.3] [192.0.2.1 192.0.2.2 192.0.2.3] [192.0.2.1 192.0.2.2 192.0.2.3]]"},
+		{OrderFixed, 1, "[[192.0.2.1] [192.0.2.1] [192.0.2.1] [192.0.2.1]]"},
+		{OrderRoundRobin, 0, "[[192.0.2.1 192.0.2.2 192.0.2.3] [192.0.2.2 192.0.2.3 192.0.2.1] [192.0.2.3 192.0.2.1 192.0.2.2] [192.0.2.1 192.0.2.2 192.0.2.3]]"},
+		{OrderRoundRobin, 1, "[[192.0.2.1] [192.0.2.2] [192.0.2.3] [192.0.2.1]]"},
+	}
+	for _, c := range cases {
+		if got := fmt.Sprint(run(c.order, c.maxServed)); got != c.want {
+			t.Errorf("%s, maxServed %d: %s, want: %s", c.order, c.maxServed, got, c.want)
+		}
+	}
+
+	// RANDOM answers every active record, in an order that changes from step to step
+	steps = make([]SBProbeStep, 20)
+	random := run(OrderRandom, 0)
+	orders := map[string]bool{}
+	for _, served := range random {
+		orders[served] = true
+		records := strings.Fields(strings.Trim(served, "[]"))
+		sort.Strings(records)
+		if fmt.Sprint(records) != "[192.0.2.1 192.0.2.2 192.0.2.3]" {
+			t.Errorf("RANDOM served %s", served)
+		}
+	}
+	if len(orders) < 2 {
+		t.Errorf("RANDOM always served %v", random[0])
+	}
+
+	p := rr.Profile.(SBPoolProfile)
+	p.Order = "WEIGHTED"
+	rr.Profile = p
+	if _, err := NewSBPoolSimulator(rr); err == nil {
+		t.Error("NewSBPoolSimulator: no error for an unknown order")
+	}
+}
+
+func Test_NewSBPoolSimulator_Errors(t *testing.T) {
+	if _, err := NewSBPoolSimulator(RRSet{RData: []string{"192.0.2.1"}, Profile: RDPoolProfile{}}); err == nil {
+		t.Error("NewSBPoolSimulator: no error for an RD pool")
+	}
+	if _, err := NewSBPoolSimulator(RRSet{RData: []string{"192.0.2.1"}, Profile: SBPoolProfile{}}); err == nil {
+		t.Error("NewSBPoolSimulator: no error for missing rdataInfo")
+	}
+}
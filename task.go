@@ -1,10 +1,9 @@
 package udnssdk
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"net/http"
-	"time"
 )
 
 // TasksService provides access to the tasks resources
@@ -54,46 +53,58 @@ func TasksQueryURI(query string, offset int) string {
 
 // Select requests all tasks, with pagination
 func (s *TasksService) Select(query string) ([]Task, error) {
-	// TODO: Sane Configuration for timeouts / retries
-	maxerrs := 5
-	waittime := 5 * time.Second
-
-	// init accumulators
-	dtos := []Task{}
-	offset := 0
-	errcnt := 0
-
-	for {
-		reqDtos, ri, res, err := s.SelectWithOffset(query, offset)
-		if err != nil {
-			if res != nil && res.StatusCode >= 500 {
-				errcnt = errcnt + 1
-				if errcnt < maxerrs {
-					time.Sleep(waittime)
-					continue
-				}
-			}
-			return dtos, err
-		}
+	return s.SelectContext(context.Background(), query)
+}
 
-		log.Printf("[DEBUG] ResultInfo: %+v\n", ri)
-		for _, d := range reqDtos {
-			dtos = append(dtos, d)
-		}
-		if ri.ReturnedCount+ri.Offset >= ri.TotalCount {
-			return dtos, nil
-		}
-		offset = ri.ReturnedCount + ri.Offset
-		continue
-	}
+// SelectContext is Select with a context.Context
+func (s *TasksService) SelectContext(ctx context.Context, query string) ([]Task, error) {
+	return NewPager(s.client, func(ctx context.Context, _ QueryInfo, offset int) ([]Task, ResultInfo, *http.Response, error) {
+		return s.SelectWithOffsetContext(ctx, query, offset)
+	}).Collect(ctx)
 }
 
 // SelectWithOffset request tasks by query & offset, list them also returning list metadata, the actual response, or an error
 func (s *TasksService) SelectWithOffset(query string, offset int) ([]Task, ResultInfo, *http.Response, error) {
+	return s.SelectWithOffsetContext(context.Background(), query, offset)
+}
+
+// SelectWithOffsetContext is SelectWithOffset with a context.Context
+func (s *TasksService) SelectWithOffsetContext(ctx context.Context, query string, offset int) ([]Task, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, TasksQueryURI(query, offset))
+}
+
+// SelectQuery requests all tasks matching q, with pagination
+func (s *TasksService) SelectQuery(q Query) ([]Task, error) {
+	return s.SelectQueryContext(context.Background(), q)
+}
+
+// SelectQueryContext is SelectQuery with a context.Context
+func (s *TasksService) SelectQueryContext(ctx context.Context, q Query) ([]Task, error) {
+	return s.Pager(q).Collect(ctx)
+}
+
+// SelectQueryWithOffset request tasks matching q from offset, list them also returning list metadata, the actual response, or an error
+func (s *TasksService) SelectQueryWithOffset(q Query, offset int) ([]Task, ResultInfo, *http.Response, error) {
+	return s.SelectQueryWithOffsetContext(context.Background(), q, offset)
+}
+
+// SelectQueryWithOffsetContext is SelectQueryWithOffset with a context.Context
+func (s *TasksService) SelectQueryWithOffsetContext(ctx context.Context, q Query, offset int) ([]Task, ResultInfo, *http.Response, error) {
+	return s.selectURI(ctx, q.URI("tasks", offset))
+}
+
+// Pager returns a Pager over the tasks matching q
+func (s *TasksService) Pager(q Query) *Pager[Task] {
+	return newQueryPager(s.client, q, func(ctx context.Context, q Query, offset int) ([]Task, ResultInfo, *http.Response, error) {
+		return s.SelectQueryWithOffsetContext(ctx, q, offset)
+	})
+}
+
+// selectURI requests a page of tasks from uri
+func (s *TasksService) selectURI(ctx context.Context, uri string) ([]Task, ResultInfo, *http.Response, error) {
 	var tld TaskListDTO
 
-	uri := TasksQueryURI(query, offset)
-	res, err := s.client.get(uri, &tld)
+	res, err := s.client.get(ctx, uri, &tld)
 
 	ts := []Task{}
 	for _, t := range tld.Tasks {
@@ -104,22 +115,71 @@ func (s *TasksService) SelectWithOffset(query string, offset int) ([]Task, Resul
 
 // Find Get the status of a task.
 func (s *TasksService) Find(t TaskID) (Task, *http.Response, error) {
+	return s.FindContext(context.Background(), t)
+}
+
+// FindContext is Find with a context.Context
+func (s *TasksService) FindContext(ctx context.Context, t TaskID) (Task, *http.Response, error) {
 	var tv Task
-	res, err := s.client.get(t.URI(), &tv)
+	res, err := s.client.get(ctx, t.URI(), &tv)
 	return tv, res, err
 }
 
 // FindResult requests
 func (s *TasksService) FindResult(t TaskID) (*http.Response, error) {
-	return s.client.GetResultByURI(t.ResultURI())
+	return s.FindResultContext(context.Background(), t)
+}
+
+// FindResultContext is FindResult with a context.Context
+func (s *TasksService) FindResultContext(ctx context.Context, t TaskID) (*http.Response, error) {
+	return s.client.GetResultByURIContext(ctx, t.ResultURI())
 }
 
 // FindResultByTask  requests a task by the provided task's result uri
 func (s *TasksService) FindResultByTask(t Task) (*http.Response, error) {
-	return s.client.GetResultByURI(t.ResultURI)
+	return s.FindResultByTaskContext(context.Background(), t)
+}
+
+// FindResultByTaskContext is FindResultByTask with a context.Context
+func (s *TasksService) FindResultByTaskContext(ctx context.Context, t Task) (*http.Response, error) {
+	return s.client.GetResultByURIContext(ctx, t.ResultURI)
 }
 
 // Delete requests deletions
 func (s *TasksService) Delete(t TaskID) (*http.Response, error) {
-	return s.client.delete(t.URI(), nil)
+	return s.DeleteContext(context.Background(), t)
+}
+
+// DeleteContext is Delete with a context.Context
+func (s *TasksService) DeleteContext(ctx context.Context, t TaskID) (*http.Response, error) {
+	return s.client.delete(ctx, t.URI(), nil)
+}
+
+// Wait polls a task until it finishes, using the Client's TaskWaiter
+func (s *TasksService) Wait(t TaskID) (Task, error) {
+	return s.WaitContext(context.Background(), t)
+}
+
+// WaitContext is Wait with a context.Context.
+// A TaskWaiter attached with ContextWithTaskWaiter takes precedence over the Client's.
+func (s *TasksService) WaitContext(ctx context.Context, t TaskID) (Task, error) {
+	return s.client.taskWaiter(ctx).Wait(ctx, s, t)
+}
+
+// WaitAll waits on every task, returning their final states in order and the first error encountered.
+// A failed task does not stop the wait on the remaining ones.
+func (s *TasksService) WaitAll(ctx context.Context, ts ...TaskID) ([]Task, error) {
+	tasks := make([]Task, len(ts))
+	var first error
+	for i, t := range ts {
+		task, err := s.WaitContext(ctx, t)
+		tasks[i] = task
+		if err != nil && first == nil {
+			first = err
+		}
+		if ctx.Err() != nil {
+			return tasks, ctx.Err()
+		}
+	}
+	return tasks, first
 }
@@ -0,0 +1,134 @@
+package udnssdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TaskWaiter describes how deferred tasks, returned by the API as
+// 202 Accepted responses, are polled until they finish.
+type TaskWaiter struct {
+	// PollInterval is the wait before the first poll of the task.
+	// Values of zero or less use one second.
+	PollInterval time.Duration
+	// Backoff multiplies the interval after each poll. Values below 1 keep the interval constant.
+	Backoff float64
+	// MaxPollInterval caps the interval as it grows by Backoff. Zero means no cap.
+	MaxPollInterval time.Duration
+	// Timeout bounds the total wait for a task. Zero means wait until the context is done.
+	Timeout time.Duration
+	// Async makes Client.Do return the 202 response immediately instead of waiting.
+	// The task can be recovered with TaskIDFromResponse and waited on with TasksService.Wait.
+	Async bool
+}
+
+// DefaultTaskWaiter returns the TaskWaiter used by NewClient
+func DefaultTaskWaiter() TaskWaiter {
+	return TaskWaiter{
+		PollInterval:    time.Second,
+		Backoff:         2,
+		MaxPollInterval: 10 * time.Second,
+		Timeout:         5 * time.Minute,
+	}
+}
+
+// TaskFailedError is returned when a deferred task finishes with an ERROR status
+type TaskFailedError struct {
+	Task Task
+}
+
+// Error implements the error interface.
+func (e *TaskFailedError) Error() string {
+	return fmt.Sprintf("task %s failed: %s", e.Task.TaskID, e.Task.Message)
+}
+
+// TaskTimeoutError is returned when a deferred task is still pending once the TaskWaiter's Timeout elapses
+type TaskTimeoutError struct {
+	Task    Task
+	Timeout time.Duration
+}
+
+// Error implements the error interface.
+func (e *TaskTimeoutError) Error() string {
+	return fmt.Sprintf("task %s still %s after %s", e.Task.TaskID, e.Task.TaskStatusCode, e.Timeout)
+}
+
+type taskWaiterKey struct{}
+
+// ContextWithTaskWaiter returns a copy of ctx which makes requests use w instead of the Client's TaskWaiter.
+// For example, ContextWithTaskWaiter(ctx, TaskWaiter{Async: true}) submits a change without waiting on it.
+func ContextWithTaskWaiter(ctx context.Context, w TaskWaiter) context.Context {
+	return context.WithValue(ctx, taskWaiterKey{}, w)
+}
+
+// taskWaiter returns the TaskWaiter in effect for ctx
+func (c *Client) taskWaiter(ctx context.Context) TaskWaiter {
+	if w, ok := ctx.Value(taskWaiterKey{}).(TaskWaiter); ok {
+		return w
+	}
+	return c.TaskWaiter
+}
+
+// TaskIDFromResponse extracts the deferred task identifier from a 202 Accepted response
+func TaskIDFromResponse(r *http.Response) (TaskID, bool) {
+	if r == nil || r.StatusCode != http.StatusAccepted {
+		return "", false
+	}
+	tid := r.Header.Get("X-Task-Id")
+	return TaskID(tid), tid != ""
+}
+
+// Wait polls the task tid until it is COMPLETE, returning a *TaskFailedError if it ends in ERROR,
+// a *TaskTimeoutError if it outlasts Timeout, or the context's error if ctx is done first.
+func (w TaskWaiter) Wait(ctx context.Context, s *TasksService, tid TaskID) (Task, error) {
+	wctx := ctx
+	if w.Timeout > 0 {
+		var cancel context.CancelFunc
+		wctx, cancel = context.WithTimeout(ctx, w.Timeout)
+		defer cancel()
+	}
+
+	interval := w.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	t := Task{TaskID: string(tid)}
+	for i := 0; ; i++ {
+		if err := sleepContext(wctx, interval); err != nil {
+			return t, w.waitError(ctx, t, err)
+		}
+
+		nt, _, err := s.FindContext(wctx, tid)
+		if err != nil {
+			return t, w.waitError(ctx, t, err)
+		}
+		t = nt
+		s.client.logger().Debug("Polled task", "taskId", tid, "poll", i, "taskStatusCode", t.TaskStatusCode)
+
+		switch t.TaskStatusCode {
+		case "COMPLETE":
+			return t, nil
+		case "ERROR":
+			return t, &TaskFailedError{Task: t}
+		}
+
+		if w.Backoff > 1 {
+			interval = time.Duration(float64(interval) * w.Backoff)
+		}
+		if w.MaxPollInterval > 0 && interval > w.MaxPollInterval {
+			interval = w.MaxPollInterval
+		}
+	}
+}
+
+// waitError reports err as a *TaskTimeoutError when it was caused by the TaskWaiter's own Timeout rather than ctx
+func (w TaskWaiter) waitError(ctx context.Context, t Task, err error) error {
+	if w.Timeout > 0 && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return &TaskTimeoutError{Task: t, Timeout: w.Timeout}
+	}
+	return err
+}
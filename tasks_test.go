@@ -1,7 +1,15 @@
 package udnssdk
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func Test_ListTasks(t *testing.T) {
@@ -20,3 +28,118 @@ func Test_ListTasks(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// newTaskServer serves a 202 for any zones request, and reports the task with the given statuses on successive polls
+func newTaskServer(statuses ...Task) (*httptest.Server, *int) {
+	polls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/result"):
+			fmt.Fprintln(w, `{"accountName":"terraform"}`)
+		case strings.Contains(r.URL.Path, "/tasks/"):
+			t := statuses[len(statuses)-1]
+			if polls < len(statuses) {
+				t = statuses[polls]
+			}
+			polls++
+			mess, _ := json.Marshal(t)
+			fmt.Fprintln(w, string(mess))
+		default:
+			w.Header().Set("X-Task-Id", "0ff1ce")
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	return ts, &polls
+}
+
+func testTaskClient(ts *httptest.Server) *Client {
+	c, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	c.TaskWaiter = TaskWaiter{PollInterval: time.Millisecond}
+	return c
+}
+
+func Test_Do_WaitsForTask(t *testing.T) {
+	ts, polls := newTaskServer(
+		Task{TaskID: "0ff1ce", TaskStatusCode: "PENDING"},
+		Task{TaskID: "0ff1ce", TaskStatusCode: "IN_PROCESS"},
+		Task{TaskID: "0ff1ce", TaskStatusCode: "COMPLETE", ResultURI: "tasks/0ff1ce/result"},
+	)
+	defer ts.Close()
+	c := testTaskClient(ts)
+
+	var a Account
+	_, err := c.Do("GET", "accounts/terraform", nil, &a)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *polls != 3 {
+		t.Errorf("polls: %d, want: %d", *polls, 3)
+	}
+	if a.AccountName != "terraform" {
+		t.Errorf("AccountName: %q, want: %q", a.AccountName, "terraform")
+	}
+}
+
+func Test_Do_TaskFailed(t *testing.T) {
+	ts, _ := newTaskServer(
+		Task{TaskID: "0ff1ce", TaskStatusCode: "ERROR", Message: "Record already exists"},
+	)
+	defer ts.Close()
+	c := testTaskClient(ts)
+
+	_, err := c.RRSets.Create(RRSetKey{Zone: "basedomain.example", Type: "A", Name: "foo"}, RRSet{})
+
+	var tfe *TaskFailedError
+	if !errors.As(err, &tfe) {
+		t.Fatalf("err: %#v, want: *TaskFailedError", err)
+	}
+	if tfe.Task.Message != "Record already exists" {
+		t.Errorf("Message: %q, want: %q", tfe.Task.Message, "Record already exists")
+	}
+}
+
+func Test_Do_TaskTimeout(t *testing.T) {
+	ts, _ := newTaskServer(Task{TaskID: "0ff1ce", TaskStatusCode: "PENDING"})
+	defer ts.Close()
+	c := testTaskClient(ts)
+	c.TaskWaiter.Timeout = 20 * time.Millisecond
+
+	_, err := c.RRSets.Delete(RRSetKey{Zone: "basedomain.example", Type: "A", Name: "foo"})
+
+	var tte *TaskTimeoutError
+	if !errors.As(err, &tte) {
+		t.Fatalf("err: %#v, want: *TaskTimeoutError", err)
+	}
+	if tte.Task.TaskStatusCode != "PENDING" {
+		t.Errorf("TaskStatusCode: %q, want: %q", tte.Task.TaskStatusCode, "PENDING")
+	}
+}
+
+func Test_Do_AsyncTasks(t *testing.T) {
+	ts, polls := newTaskServer(Task{TaskID: "0ff1ce", TaskStatusCode: "COMPLETE"})
+	defer ts.Close()
+	c := testTaskClient(ts)
+
+	ctx := ContextWithTaskWaiter(context.Background(), TaskWaiter{Async: true})
+	res, err := c.RRSets.DeleteContext(ctx, RRSetKey{Zone: "basedomain.example", Type: "A", Name: "foo"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *polls != 0 {
+		t.Errorf("polls: %d, want: %d", *polls, 0)
+	}
+	tid, ok := TaskIDFromResponse(res)
+	if !ok || tid != "0ff1ce" {
+		t.Fatalf("TaskIDFromResponse: %q %v, want: %q", tid, ok, "0ff1ce")
+	}
+
+	tasks, err := c.Tasks.WaitAll(context.Background(), tid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || tasks[0].TaskStatusCode != "COMPLETE" {
+		t.Errorf("tasks: %+v, want one COMPLETE task", tasks)
+	}
+}
@@ -5,17 +5,15 @@ package udnssdk
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"strings"
-	"time"
-
-	"golang.org/x/oauth2"
 
 	oauthPassword "github.com/terra-farm/udnssdk/password"
 )
@@ -56,6 +54,26 @@ type Client struct {
 	BaseURL   *url.URL
 	UserAgent string
 
+	// RetryPolicy controls retries of failed requests made by Do
+	RetryPolicy RetryPolicy
+	// TaskWaiter controls how Do waits on deferred tasks
+	TaskWaiter TaskWaiter
+	// ValidateRRSets checks RRSets with RRSet.Validate before RRSets.Create and RRSets.Update send them
+	ValidateRRSets bool
+	// ValidateGeoGroups checks geo directional groups with AccountLevelGeoDirectionalGroupDTO.Validate
+	// before DirectionalPools.Geos().Create and Update send them
+	ValidateGeoGroups bool
+	// ValidateIPGroups checks IP directional groups with AccountLevelIPDirectionalGroupDTO.Validate
+	// before DirectionalPools.IPs().Create and Update send them
+	ValidateIPGroups bool
+	// ValidateProbes checks probes with ProbeInfoDTO.Validate before Probes.Create and Update,
+	// or a Batch, send them
+	ValidateProbes bool
+	// Logger receives diagnostic messages; nothing is logged if nil
+	Logger Logger
+	// LogBodies adds request and response bodies, with secrets redacted, to the logged messages
+	LogBodies bool
+
 	// Accounts API
 	Accounts *AccountsService
 	// Probe Alerts API
@@ -72,33 +90,17 @@ type Client struct {
 	RRSets *RRSetsService
 	// Tasks API
 	Tasks *TasksService
+	// Zones API
+	Zones *ZonesService
 }
 
 // NewClient returns a new ultradns API client.
+// See NewClientWithOptions for further configuration.
 func NewClient(username, password, baseURL string) (*Client, error) {
-	ctx := oauth2.NoContext
-	conf := NewConfig(username, password, baseURL)
-
-	u, err := url.Parse(baseURL)
-	if err != nil {
-		return nil, err
-	}
-
-	c := &Client{
-		HTTPClient: conf.Client(ctx),
-		BaseURL:    u,
-		UserAgent:  userAgent,
-		Config:     conf,
-	}
-	c.Accounts = &AccountsService{client: c}
-	c.Alerts = &AlertsService{client: c}
-	c.DirectionalPools = &DirectionalPoolsService{client: c}
-	c.Events = &EventsService{client: c}
-	c.Notifications = &NotificationsService{client: c}
-	c.Probes = &ProbesService{client: c}
-	c.RRSets = &RRSetsService{client: c}
-	c.Tasks = &TasksService{client: c}
-	return c, nil
+	return NewClientWithOptions(
+		WithCredentials(username, password),
+		WithBaseURL(baseURL),
+	)
 }
 
 // newStubClient returns a new ultradns API client.
@@ -112,7 +114,16 @@ func newStubClient(username, password, baseURL, clientID, clientSecret string) (
 		HTTPClient: &http.Client{},
 		BaseURL:    u,
 		UserAgent:  userAgent,
+
+		RetryPolicy: DefaultRetryPolicy(),
+		TaskWaiter:  DefaultTaskWaiter(),
 	}
+	c.initServices()
+	return c, nil
+}
+
+// initServices attaches the API services to the Client
+func (c *Client) initServices() {
 	c.Accounts = &AccountsService{client: c}
 	c.Alerts = &AlertsService{client: c}
 	c.DirectionalPools = &DirectionalPoolsService{client: c}
@@ -121,13 +132,20 @@ func newStubClient(username, password, baseURL, clientID, clientSecret string) (
 	c.Probes = &ProbesService{client: c}
 	c.RRSets = &RRSetsService{client: c}
 	c.Tasks = &TasksService{client: c}
-	return c, nil
+	c.Zones = &ZonesService{client: c}
 }
 
 // NewRequest creates an API request.
 // The path is expected to be a relative path and will be resolved
 // according to the BaseURL of the Client. Paths should always be specified without a preceding slash.
+// The payload is sent as JSON, with the media type of its ContentType method if it has one.
 func (c *Client) NewRequest(method, pathquery string, payload interface{}) (*http.Request, error) {
+	return c.NewRequestContext(context.Background(), method, pathquery, payload)
+}
+
+// NewRequestContext creates an API request bound to ctx.
+// See NewRequest for how the path is resolved.
+func (c *Client) NewRequestContext(ctx context.Context, method, pathquery string, payload interface{}) (*http.Request, error) {
 	url := *c.BaseURL
 
 	pq := strings.SplitN(pathquery, "?", 2)
@@ -144,32 +162,39 @@ func (c *Client) NewRequest(method, pathquery string, payload interface{}) (*htt
 		}
 	}
 
-	req, err := http.NewRequest(method, url.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if ct, ok := payload.(interface{ ContentType() string }); ok {
+		req.Header.Set("Content-Type", ct.ContentType())
+	}
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("User-Agent", c.UserAgent)
 
 	return req, nil
 }
 
-func (c *Client) get(path string, v interface{}) (*http.Response, error) {
-	return c.Do("GET", path, nil, v)
+func (c *Client) get(ctx context.Context, path string, v interface{}) (*http.Response, error) {
+	return c.DoContext(ctx, "GET", path, nil, v)
+}
+
+func (c *Client) post(ctx context.Context, path string, payload, v interface{}) (*http.Response, error) {
+	return c.DoContext(ctx, "POST", path, payload, v)
 }
 
-func (c *Client) post(path string, payload, v interface{}) (*http.Response, error) {
-	return c.Do("POST", path, payload, v)
+func (c *Client) put(ctx context.Context, path string, payload, v interface{}) (*http.Response, error) {
+	return c.DoContext(ctx, "PUT", path, payload, v)
 }
 
-func (c *Client) put(path string, payload, v interface{}) (*http.Response, error) {
-	return c.Do("PUT", path, payload, v)
+func (c *Client) patch(ctx context.Context, path string, payload, v interface{}) (*http.Response, error) {
+	return c.DoContext(ctx, "PATCH", path, payload, v)
 }
 
-func (c *Client) delete(path string, payload interface{}) (*http.Response, error) {
-	return c.Do("DELETE", path, payload, nil)
+func (c *Client) delete(ctx context.Context, path string, payload interface{}) (*http.Response, error) {
+	return c.DoContext(ctx, "DELETE", path, payload, nil)
 }
 
 // Do sends an API request and returns the API response.
@@ -178,51 +203,41 @@ func (c *Client) delete(path string, payload interface{}) (*http.Response, error
 // If v implements the io.Writer interface, the raw response body will be written to v,
 // without attempting to decode it.
 func (c *Client) Do(method, path string, payload, v interface{}) (*http.Response, error) {
-	hc := c.HTTPClient
-	req, err := c.NewRequest(method, path, payload)
-	if err != nil {
-		return nil, err
-	}
-	log.Printf("[DEBUG] HTTP Request: %+v\n", req)
-	r, err := hc.Do(req)
-	log.Printf("[DEBUG] HTTP Response: %+v\n", r)
+	return c.DoContext(context.Background(), method, path, payload, v)
+}
+
+// DoContext is Do with a context.Context.
+// The context applies to the request, any retries and the polling of deferred tasks.
+// A 202 Accepted response is waited on according to the TaskWaiter in effect;
+// a task that ends in ERROR is returned as a *TaskFailedError.
+func (c *Client) DoContext(ctx context.Context, method, path string, payload, v interface{}) (*http.Response, error) {
+	r, err := c.send(ctx, method, path, payload)
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
-	if r.StatusCode == 202 {
+	if tid, ok := TaskIDFromResponse(r); ok {
 		// This is a deferred task.
-		tid := TaskID(r.Header.Get("X-Task-Id"))
-		log.Printf("[DEBUG] Received Async Task %+v..  will retry...\n", tid)
-		// TODO: Sane Configuration for timeouts / retries
-		timeout := 5
-		waittime := 5 * time.Second
-		i := 0
-		breakmeout := false
-		for i < timeout || breakmeout {
-			t, _, err := c.Tasks.Find(tid)
-			if err != nil {
-				return nil, err
-			}
-			log.Printf("[DEBUG] Task ID: %+v Retry: %d Status Code: %s\n", tid, i, t.TaskStatusCode)
-			switch t.TaskStatusCode {
-			case "COMPLETE":
-				// Yay
-				resp, err := c.Tasks.FindResultByTask(t)
-				if err != nil {
-					return nil, err
-				}
-				r = resp
-				breakmeout = true
-			case "PENDING", "IN_PROCESS":
-				i = i + 1
-				time.Sleep(waittime)
-				continue
-			case "ERROR":
-				return nil, err
-			}
+		w := c.taskWaiter(ctx)
+		if w.Async {
+			c.logger().Debug("Received async task, returning without waiting", "taskId", tid)
+			return r, nil
+		}
+		c.logger().Debug("Received async task, waiting", "taskId", tid)
+		t, err := w.Wait(ctx, c.Tasks, tid)
+		if err != nil {
+			return r, err
 		}
+		if t.ResultURI == "" {
+			return r, nil
+		}
+		resp, err := c.Tasks.FindResultByTaskContext(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		r = resp
 	}
 
 	err = CheckResponse(r)
@@ -242,6 +257,42 @@ func (c *Client) Do(method, path string, payload, v interface{}) (*http.Response
 	return r, err
 }
 
+// send builds and sends a request, retrying according to the Client's RetryPolicy.
+// A new request is built for every attempt so the payload is sent in full each time.
+func (c *Client) send(ctx context.Context, method, path string, payload interface{}) (*http.Response, error) {
+	p := c.RetryPolicy
+	for attempt := 0; ; attempt++ {
+		req, err := c.NewRequestContext(ctx, method, path, payload)
+		if err != nil {
+			return nil, err
+		}
+		sent := false
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+			WroteRequest: func(httptrace.WroteRequestInfo) { sent = true },
+		}))
+		c.logRequest(req)
+		r, err := c.HTTPClient.Do(req)
+		c.logResponse(r, err)
+		if err != nil {
+			err = tokenError(err)
+		}
+
+		if attempt+1 >= p.attempts() || !p.retryable(ctx, method, r, err, sent) {
+			return r, err
+		}
+
+		wait := p.backoff(attempt, r)
+		if r != nil {
+			io.Copy(ioutil.Discard, r.Body)
+			r.Body.Close()
+		}
+		c.logger().Warn("Retrying request", "method", method, "path", path, "wait", wait, "attempt", attempt+2, "maxAttempts", p.attempts())
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
 // ErrorResponse represents an error caused by an API request.
 // Example:
 // {"errorCode":60001,"errorMessage":"invalid_grant:Invalid username & password combination.","error":"invalid_grant","error_description":"60001: invalid_grant:Invalid username & password combination."}
@@ -261,15 +312,48 @@ type ErrorResponseList struct {
 
 // Error implements the error interface.
 func (r ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %d %v",
-		r.Response.Request.Method, r.Response.Request.URL,
-		r.Response.StatusCode, r.ErrorCode, r.ErrorMessage)
+	return fmt.Sprintf("%s: %d %d %v", requestString(r.Response), statusCode(r.Response), r.ErrorCode, r.message())
 }
 
+// message returns the most descriptive message of the ErrorResponse
+func (r ErrorResponse) message() string {
+	if r.ErrorMessage == "" && r.ErrorDescription != "" {
+		return r.ErrorDescription
+	}
+	return r.ErrorMessage
+}
+
+// Error implements the error interface.
 func (r ErrorResponseList) Error() string {
-	return fmt.Sprintf("%v %v: %d %d %v",
-		r.Response.Request.Method, r.Response.Request.URL,
-		r.Response.StatusCode, r.Responses[0].ErrorCode, r.Responses[0].ErrorMessage)
+	if len(r.Responses) == 0 {
+		return fmt.Sprintf("%s: %d", requestString(r.Response), statusCode(r.Response))
+	}
+	return fmt.Sprintf("%s: %d %d %v",
+		requestString(r.Response), statusCode(r.Response),
+		r.Responses[0].ErrorCode, r.Responses[0].message())
+}
+
+// Unwrap returns each ErrorResponse of the list, so errors.As can extract them
+func (r ErrorResponseList) Unwrap() []error {
+	errs := make([]error, len(r.Responses))
+	for i, e := range r.Responses {
+		if e.Response == nil {
+			e.Response = r.Response
+		}
+		errs[i] = e
+	}
+	return errs
+}
+
+// StatusError is returned by CheckResponse for an unsuccessful response whose body has no recognizable errors
+type StatusError struct {
+	Response *http.Response // HTTP response that caused this error
+	Body     string
+}
+
+// Error implements the error interface.
+func (r *StatusError) Error() string {
+	return fmt.Sprintf("Response had non-successful Status: %#v, but could not extract any errors from Body: %#v", r.Response.Status, r.Body)
 }
 
 // CheckResponse checks the API response for errors, and returns them if present.
@@ -300,5 +384,5 @@ func CheckResponse(r *http.Response) error {
 		return &ErrorResponseList{Response: r, Responses: ers}
 	}
 
-	return fmt.Errorf("Response had non-successful Status: %#v, but could not extract any errors from Body: %#v", r.Status, string(body))
+	return &StatusError{Response: r, Body: string(body)}
 }
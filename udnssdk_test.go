@@ -1,11 +1,14 @@
 package udnssdk
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -30,7 +33,7 @@ var (
 	testIPDPoolDescr   = "A Test IP Directional Pool Group"
 	testIPAddrDTO      = IPAddrDTO{Address: "127.0.0.1"}
 	testIPDPool        = AccountLevelIPDirectionalGroupDTO{Name: "testippool", Description: "An IP Test Pool", IPs: []IPAddrDTO{IPAddrDTO{Address: "127.0.0.1"}}}
-	testGeoDPool       = AccountLevelGeoDirectionalGroupDTO{Name: "testgeopool", Description: "A test geo pool", Codes: []string{"US, UK"}}
+	testGeoDPool       = AccountLevelGeoDirectionalGroupDTO{Name: "testgeopool", Description: "A test geo pool", Codes: []string{"US", "GB"}}
 	testGeoDPoolName   = "testgeodpool"
 	testGeoDPoolDescr  = "A Test Geo Directional Pool Group"
 	testGeoDPoolCodes  = []string{"US", "UK"}
@@ -208,3 +211,38 @@ func Test_CheckResponse_StatusCode4xx(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func Test_DoContext_Canceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+	c, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.RRSets.SelectContext(ctx, RRSetKey{Zone: "basedomain.example"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v; want: %v", err, context.DeadlineExceeded)
+	}
+}
+
+func Test_NewClient_TokenFetchUsesRequestContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+	c, err := NewClient(testUsername, testPassword, ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = c.DoContext(ctx, "GET", "zones", nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v; want: %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,246 @@
+package udnstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/terra-farm/udnssdk"
+)
+
+// patchRRSet applies body to rr: an RFC 6902 JSON Patch if it is an array, or an
+// UltraDNS merge-style patch replacing the ttl, rdata and profile it sets if it is an object
+func patchRRSet(rr udnssdk.RRSet, body []byte) (udnssdk.RRSet, *apiError) {
+	b, _ := json.Marshal(rr)
+	var doc interface{}
+	json.Unmarshal(b, &doc)
+
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		var ops []map[string]interface{}
+		if err := json.Unmarshal(body, &ops); err != nil {
+			return rr, badRequest("Invalid json: %v", err)
+		}
+		for i, op := range ops {
+			var err error
+			if doc, err = applyPatchOperation(doc, op); err != nil {
+				return rr, badRequest("Patch operation %d: %v", i, err)
+			}
+		}
+	} else {
+		var merge map[string]interface{}
+		if err := json.Unmarshal(body, &merge); err != nil {
+			return rr, badRequest("Invalid json: %v", err)
+		}
+		m := doc.(map[string]interface{})
+		for _, k := range []string{"ttl", "rdata", "profile"} {
+			if v, ok := merge[k]; ok {
+				m[k] = v
+			}
+		}
+	}
+
+	b, _ = json.Marshal(doc)
+	var patched udnssdk.RRSet
+	if err := json.Unmarshal(b, &patched); err != nil {
+		return rr, badRequest("Invalid resource record: %v", err)
+	}
+	if len(patched.RData) == 0 {
+		return rr, badRequest("rdata is required")
+	}
+	if profile, ok := doc.(map[string]interface{})["profile"].(map[string]interface{}); ok {
+		if infos, ok := profile["rdataInfo"].([]interface{}); ok && len(infos) != len(patched.RData) {
+			return rr, badRequest("The pool has %d rdata and %d rdataInfo.", len(patched.RData), len(infos))
+		}
+	}
+	return patched, nil
+}
+
+// applyPatchOperation applies a JSON Patch operation to doc, returning the new document
+func applyPatchOperation(doc interface{}, op map[string]interface{}) (interface{}, error) {
+	path, _ := op["path"].(string)
+	from, _ := op["from"].(string)
+	switch op["op"] {
+	case udnssdk.PatchAdd:
+		return setPointer(doc, path, op["value"], true)
+	case udnssdk.PatchReplace:
+		if _, err := getPointer(doc, path); err != nil {
+			return nil, err
+		}
+		return setPointer(doc, path, op["value"], false)
+	case udnssdk.PatchRemove:
+		return removePointer(doc, path)
+	case udnssdk.PatchTest:
+		v, err := getPointer(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(v, op["value"]) {
+			return nil, fmt.Errorf("test failed at %s", path)
+		}
+		return doc, nil
+	case udnssdk.PatchMove, udnssdk.PatchCopy:
+		v, err := getPointer(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op["op"] == udnssdk.PatchMove {
+			if doc, err = removePointer(doc, from); err != nil {
+				return nil, err
+			}
+		}
+		return setPointer(doc, path, v, true)
+	}
+	return nil, fmt.Errorf("unknown op %v", op["op"])
+}
+
+// pointerTokens splits an RFC 6901 JSON Pointer into its unescaped tokens
+func pointerTokens(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid path %q", path)
+	}
+	toks := strings.Split(path[1:], "/")
+	r := strings.NewReplacer("~1", "/", "~0", "~")
+	for i, t := range toks {
+		toks[i] = r.Replace(t)
+	}
+	return toks, nil
+}
+
+func getPointer(doc interface{}, path string) (interface{}, error) {
+	toks, err := pointerTokens(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range toks {
+		switch v := doc.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if doc, ok = v[t]; !ok {
+				return nil, fmt.Errorf("no member %q in %s", t, path)
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(t)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("no index %q in %s", t, path)
+			}
+			doc = v[i]
+		default:
+			return nil, fmt.Errorf("%s does not exist", path)
+		}
+	}
+	return doc, nil
+}
+
+// setPointer sets the value at path, inserting it into arrays if insert is set
+func setPointer(doc interface{}, path string, value interface{}, insert bool) (interface{}, error) {
+	toks, err := pointerTokens(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) == 0 {
+		return value, nil
+	}
+	return setTokens(doc, toks, value, insert)
+}
+
+func setTokens(doc interface{}, toks []string, value interface{}, insert bool) (interface{}, error) {
+	t := toks[0]
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		if len(toks) == 1 {
+			v[t] = value
+			return v, nil
+		}
+		child, ok := v[t]
+		if !ok {
+			return nil, fmt.Errorf("no member %q", t)
+		}
+		child, err := setTokens(child, toks[1:], value, insert)
+		if err != nil {
+			return nil, err
+		}
+		v[t] = child
+		return v, nil
+	case []interface{}:
+		i := len(v)
+		if t != "-" {
+			var err error
+			if i, err = strconv.Atoi(t); err != nil || i < 0 || i > len(v) || (i == len(v) && !(insert && len(toks) == 1)) {
+				return nil, fmt.Errorf("no index %q", t)
+			}
+		}
+		if len(toks) == 1 {
+			if insert {
+				v = append(v[:i], append([]interface{}{value}, v[i:]...)...)
+			} else if i < len(v) {
+				v[i] = value
+			} else {
+				return nil, fmt.Errorf("no index %q", t)
+			}
+			return v, nil
+		}
+		if i >= len(v) {
+			return nil, fmt.Errorf("no index %q", t)
+		}
+		child, err := setTokens(v[i], toks[1:], value, insert)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = child
+		return v, nil
+	}
+	return nil, fmt.Errorf("cannot set %q in a %T", t, doc)
+}
+
+func removePointer(doc interface{}, path string) (interface{}, error) {
+	toks, err := pointerTokens(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) == 0 {
+		return nil, fmt.Errorf("cannot remove the whole document")
+	}
+	return removeTokens(doc, toks)
+}
+
+func removeTokens(doc interface{}, toks []string) (interface{}, error) {
+	t := toks[0]
+	switch v := doc.(type) {
+	case map[string]interface{}:
+		child, ok := v[t]
+		if !ok {
+			return nil, fmt.Errorf("no member %q", t)
+		}
+		if len(toks) == 1 {
+			delete(v, t)
+			return v, nil
+		}
+		child, err := removeTokens(child, toks[1:])
+		if err != nil {
+			return nil, err
+		}
+		v[t] = child
+		return v, nil
+	case []interface{}:
+		i, err := strconv.Atoi(t)
+		if err != nil || i < 0 || i >= len(v) {
+			return nil, fmt.Errorf("no index %q", t)
+		}
+		if len(toks) == 1 {
+			return append(v[:i], v[i+1:]...), nil
+		}
+		child, err := removeTokens(v[i], toks[1:])
+		if err != nil {
+			return nil, err
+		}
+		v[i] = child
+		return v, nil
+	}
+	return nil, fmt.Errorf("cannot remove %q from a %T", t, doc)
+}
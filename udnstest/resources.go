@@ -0,0 +1,856 @@
+package udnstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/terra-farm/udnssdk"
+)
+
+// rrTypeCodes maps record type names to their RFC numeric codes, as rendered by the API in "A (1)" form
+var rrTypeCodes = map[string]int{
+	"A":     1,
+	"NS":    2,
+	"CNAME": 5,
+	"SOA":   6,
+	"PTR":   12,
+	"HINFO": 13,
+	"MX":    15,
+	"TXT":   16,
+	"RP":    17,
+	"AAAA":  28,
+	"SRV":   33,
+	"NAPTR": 35,
+	"DS":    43,
+	"SSHFP": 44,
+	"TLSA":  52,
+	"SPF":   99,
+	"CAA":   257,
+}
+
+// canonicalZone returns the lowercase, absolute form of a zone name
+func canonicalZone(name string) string {
+	name = strings.ToLower(name)
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+	return name
+}
+
+// absoluteName returns the lowercase, absolute form of an owner name relative to zone
+func absoluteName(name, zone string) string {
+	name = strings.ToLower(name)
+	switch {
+	case name == "" || name == "@":
+		return zone
+	case strings.HasSuffix(name, "."):
+		return name
+	case name+"." == zone:
+		return zone
+	case strings.HasSuffix(name+".", "."+zone):
+		return name + "."
+	}
+	return name + "." + zone
+}
+
+// canonicalType returns the upper case name of a record type given as "A", "a", "A (1)" or "1"
+func canonicalType(t string) string {
+	t = strings.ToUpper(strings.TrimSpace(t))
+	if i := strings.Index(t, " "); i >= 0 {
+		t = t[:i]
+	}
+	for name, code := range rrTypeCodes {
+		if t == fmt.Sprint(code) {
+			return name
+		}
+	}
+	return t
+}
+
+// displayType renders a canonical type the way the API does
+func displayType(t string) string {
+	if code, ok := rrTypeCodes[t]; ok {
+		return fmt.Sprintf("%s (%d)", t, code)
+	}
+	return t
+}
+
+// storedRRSet returns rr as the API would store and return it under id
+func storedRRSet(id rrsetID, rr udnssdk.RRSet) udnssdk.RRSet {
+	rr.OwnerName = id.owner
+	rr.RRType = displayType(id.typ)
+	if rr.TTL == 0 {
+		rr.TTL = 86400
+	}
+	rr.RData = append([]string{}, rr.RData...)
+	return rr
+}
+
+// sortedRRSets returns the RRSets selected by match, ordered by owner and type
+func (z *zone) sortedRRSets(match func(rrsetID) bool) []udnssdk.RRSet {
+	ids := []rrsetID{}
+	for id := range z.rrsets {
+		if match(id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ids[i].owner != ids[j].owner {
+			return ids[i].owner < ids[j].owner
+		}
+		return ids[i].typ < ids[j].typ
+	})
+	rrsets := make([]udnssdk.RRSet, 0, len(ids))
+	for _, id := range ids {
+		rrsets = append(rrsets, z.rrsets[id])
+	}
+	return rrsets
+}
+
+// view returns the zone resource with its read-only properties filled in
+func (z *zone) view(s *Server) udnssdk.Zone {
+	v := z.info
+	v.Properties.Status = "ACTIVE"
+	v.Properties.DNSSECStatus = "UNSIGNED"
+	v.Properties.Owner = s.Username
+	v.Properties.ResourceRecordCount = len(z.rrsets)
+	if v.SecondaryCreateInfo != nil {
+		v.PrimaryNameServers = &v.SecondaryCreateInfo.PrimaryNameServers
+	}
+	if v.AliasCreateInfo != nil {
+		v.OriginalZone = v.AliasCreateInfo.OriginalZoneName
+	}
+	v.PrimaryCreateInfo, v.SecondaryCreateInfo, v.AliasCreateInfo = nil, nil, nil
+	return v
+}
+
+func zoneNotFound() *apiError {
+	return &apiError{http.StatusNotFound, udnssdk.ErrorCodeZoneNotFound, "Zone does not exist in the system."}
+}
+
+// matchesZoneQuery reports whether z matches the space separated key:value terms of a zone query
+func matchesZoneQuery(z udnssdk.Zone, q string) bool {
+	for _, term := range strings.Fields(q) {
+		k, v, _ := strings.Cut(term, ":")
+		switch k {
+		case "name":
+			if !strings.Contains(z.Properties.Name, strings.ToLower(v)) {
+				return false
+			}
+		case "zone_type":
+			if string(z.Properties.Type) != v {
+				return false
+			}
+		case "zone_status":
+			if v != "ALL" && z.Properties.Status != v {
+				return false
+			}
+		case "account_name":
+			if z.Properties.AccountName != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// serveZones implements the zones collection and routes requests below a zone
+func (s *Server) serveZones(w http.ResponseWriter, r *http.Request, seg []string) {
+	if len(seg) == 0 {
+		s.serveZoneList(w, r)
+		return
+	}
+	z, ok := s.zones[canonicalZone(seg[0])]
+	if len(seg) == 1 {
+		s.serveZone(w, r, z, ok)
+		return
+	}
+	if seg[1] != "rrsets" {
+		writeError(w, notFound(strings.Join(append([]string{"zones"}, seg...), "/")))
+		return
+	}
+	if !ok {
+		writeError(w, zoneNotFound())
+		return
+	}
+	seg = seg[2:]
+
+	switch {
+	case len(seg) <= 2 && r.Method == http.MethodGet:
+		s.listRRSets(w, r, z, seg)
+	case len(seg) == 2:
+		s.serveRRSet(w, r, z, rrsetID{absoluteName(seg[1], z.name), canonicalType(seg[0])})
+	case len(seg) >= 3:
+		owner := absoluteName(seg[1], z.name)
+		switch seg[2] {
+		case "probes":
+			s.serveProbes(w, r, z, owner, seg[3:])
+		case "events":
+			s.serveEvents(w, r, z, owner, seg[3:])
+		case "notifications":
+			s.serveNotifications(w, r, z, owner, seg[3:])
+		case "alerts":
+			s.serveAlerts(w, r, z, owner, seg[3:])
+		default:
+			writeError(w, notFound(seg[2]))
+		}
+	default:
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+	}
+}
+
+// serveZoneList lists or creates zones
+func (s *Server) serveZoneList(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		q := queryInfo(r)
+		zones := []udnssdk.Zone{}
+		for _, name := range sortedKeys(s.zones) {
+			if v := s.zones[name].view(s); matchesZoneQuery(v, q.Q) {
+				zones = append(zones, v)
+			}
+		}
+		switch q.Sort {
+		case "ACCOUNT_NAME":
+			sort.SliceStable(zones, func(i, j int) bool { return zones[i].Properties.AccountName < zones[j].Properties.AccountName })
+		case "RECORD_COUNT":
+			sort.SliceStable(zones, func(i, j int) bool {
+				return zones[i].Properties.ResourceRecordCount < zones[j].Properties.ResourceRecordCount
+			})
+		case "ZONE_TYPE":
+			sort.SliceStable(zones, func(i, j int) bool { return zones[i].Properties.Type < zones[j].Properties.Type })
+		}
+		if q.Reverse {
+			for i, j := 0, len(zones)-1; i < j; i, j = i+1, j-1 {
+				zones[i], zones[j] = zones[j], zones[i]
+			}
+		}
+		page, ri := paginate(zones, r, s.PageSize)
+		writeJSON(w, http.StatusOK, udnssdk.ZoneListDTO{Zones: page, Queryinfo: q, Resultinfo: ri})
+	case http.MethodPost:
+		var info udnssdk.Zone
+		if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+			writeError(w, badRequest("Invalid json: %v", err))
+			return
+		}
+		s.write(w, func() (int, interface{}, *apiError) {
+			if err := s.validateZone(info); err != nil {
+				return 0, nil, err
+			}
+			if _, ok := s.zones[canonicalZone(info.Properties.Name)]; ok {
+				return 0, nil, &apiError{http.StatusBadRequest, udnssdk.ErrorCodeZoneAlreadyExists, "Zone already exists in the system."}
+			}
+			s.addZoneInfo(info)
+			return http.StatusCreated, map[string]string{"message": "Successful"}, nil
+		})
+	default:
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+	}
+}
+
+// validateZone checks a zone to be created
+func (s *Server) validateZone(z udnssdk.Zone) *apiError {
+	p := z.Properties
+	switch {
+	case p.Name == "":
+		return badRequest("Zone name is required.")
+	case p.AccountName != s.Account:
+		return &apiError{http.StatusBadRequest, 8001, fmt.Sprintf("Account %q not found.", p.AccountName)}
+	}
+	switch p.Type {
+	case udnssdk.PrimaryZoneType:
+		if z.PrimaryCreateInfo == nil {
+			return badRequest("primaryCreateInfo is required for PRIMARY zones.")
+		}
+	case udnssdk.SecondaryZoneType:
+		if z.SecondaryCreateInfo == nil || z.SecondaryCreateInfo.PrimaryNameServers.NameServerIPList.NameServerIP1 == nil {
+			return badRequest("secondaryCreateInfo with nameServerIp1 is required for SECONDARY zones.")
+		}
+	case udnssdk.AliasZoneType:
+		if z.AliasCreateInfo == nil {
+			return badRequest("aliasCreateInfo is required for ALIAS zones.")
+		}
+		if _, ok := s.zones[canonicalZone(z.AliasCreateInfo.OriginalZoneName)]; !ok {
+			return zoneNotFound()
+		}
+	default:
+		return badRequest("Invalid zone type %q.", p.Type)
+	}
+	return nil
+}
+
+// serveZone reads, replaces or deletes a single zone
+func (s *Server) serveZone(w http.ResponseWriter, r *http.Request, z *zone, ok bool) {
+	switch r.Method {
+	case http.MethodGet:
+		if !ok {
+			writeError(w, zoneNotFound())
+			return
+		}
+		writeJSON(w, http.StatusOK, z.view(s))
+	case http.MethodPut:
+		var info udnssdk.Zone
+		if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+			writeError(w, badRequest("Invalid json: %v", err))
+			return
+		}
+		s.write(w, func() (int, interface{}, *apiError) {
+			if !ok {
+				return 0, nil, zoneNotFound()
+			}
+			z.info.RestrictIPList = info.RestrictIPList
+			z.info.NotifyAddresses = info.NotifyAddresses
+			if info.PrimaryNameServers != nil && z.info.SecondaryCreateInfo != nil {
+				z.info.SecondaryCreateInfo.PrimaryNameServers = *info.PrimaryNameServers
+			}
+			return http.StatusOK, map[string]string{"message": "Successful"}, nil
+		})
+	case http.MethodDelete:
+		s.write(w, func() (int, interface{}, *apiError) {
+			if !ok {
+				return 0, nil, zoneNotFound()
+			}
+			delete(s.zones, z.name)
+			return http.StatusNoContent, nil, nil
+		})
+	default:
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+	}
+}
+
+// listRRSets lists the RRSets of a zone, optionally restricted to a type (or ANY) and owner
+func (s *Server) listRRSets(w http.ResponseWriter, r *http.Request, z *zone, seg []string) {
+	typ, owner := "ANY", ""
+	if len(seg) >= 1 && seg[0] != "" {
+		typ = canonicalType(seg[0])
+	}
+	if len(seg) == 2 {
+		owner = absoluteName(seg[1], z.name)
+	}
+	qi := queryInfo(r)
+	rrsets := []udnssdk.RRSet{}
+	for _, rr := range z.sortedRRSets(func(id rrsetID) bool {
+		return (typ == "ANY" || id.typ == typ) && (owner == "" || id.owner == owner)
+	}) {
+		if matchesRRSetQuery(rr, qi.Q) {
+			rrsets = append(rrsets, rr)
+		}
+	}
+	if owner != "" && len(rrsets) == 0 {
+		writeError(w, &apiError{http.StatusNotFound, udnssdk.ErrorCodeDataNotFound, "Data not found."})
+		return
+	}
+	sortRRSets(rrsets, qi.Sort, qi.Reverse)
+
+	page, ri := paginate(rrsets, r, s.PageSize)
+	writeJSON(w, http.StatusOK, udnssdk.RRSetListDTO{
+		ZoneName:   z.name,
+		Rrsets:     page,
+		Queryinfo:  qi,
+		Resultinfo: ri,
+	})
+}
+
+// matchesRRSetQuery reports whether rr matches the kind, owner, value, ttl and type terms of an RRSet query
+func matchesRRSetQuery(rr udnssdk.RRSet, q string) bool {
+	for _, term := range strings.Fields(q) {
+		k, v, _ := strings.Cut(term, ":")
+		switch k {
+		case "kind":
+			if !matchesKind(rr, v) {
+				return false
+			}
+		case "owner":
+			if !strings.Contains(rr.OwnerName, strings.ToLower(v)) {
+				return false
+			}
+		case "value":
+			found := false
+			for _, rdata := range rr.RData {
+				found = found || strings.Contains(strings.ToLower(rdata), strings.ToLower(v))
+			}
+			if !found {
+				return false
+			}
+		case "ttl":
+			if fmt.Sprint(rr.TTL) != v {
+				return false
+			}
+		case "type":
+			if canonicalType(rr.RRType) != canonicalType(v) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesKind reports whether rr is of the kind of RRSets named by a query
+func matchesKind(rr udnssdk.RRSet, kind string) bool {
+	schema := ""
+	if rr.Profile != nil {
+		schema = string(rr.Profile.Schema())
+	}
+	switch kind {
+	case udnssdk.RecordsKind:
+		return schema == ""
+	case udnssdk.PoolsKind:
+		return schema != ""
+	case udnssdk.RDPoolsKind:
+		return schema == string(udnssdk.RDPoolSchema)
+	case udnssdk.DirPoolsKind:
+		return schema == string(udnssdk.DirPoolSchema)
+	case udnssdk.SBPoolsKind:
+		return schema == string(udnssdk.SBPoolSchema)
+	case udnssdk.TCPoolsKind:
+		return schema == string(udnssdk.TCPoolSchema)
+	case udnssdk.SFPoolsKind:
+		return schema == string(udnssdk.SFPoolSchema)
+	case udnssdk.SLBPoolsKind:
+		return schema == string(udnssdk.SLBPoolSchema)
+	}
+	return true
+}
+
+// sortRRSets orders rrsets by the OWNER, TTL or TYPE sort key of a query, keeping their order otherwise
+func sortRRSets(rrsets []udnssdk.RRSet, key string, reverse bool) {
+	less := func(a, b udnssdk.RRSet) bool {
+		switch key {
+		case "TTL":
+			return a.TTL < b.TTL
+		case "TYPE":
+			return canonicalType(a.RRType) < canonicalType(b.RRType)
+		}
+		return a.OwnerName < b.OwnerName
+	}
+	sort.SliceStable(rrsets, func(i, j int) bool {
+		if reverse {
+			return less(rrsets[j], rrsets[i])
+		}
+		return less(rrsets[i], rrsets[j])
+	})
+}
+
+// nameTerm returns the value of the name term of a group query, or the whole query if it has no terms
+func nameTerm(q string) string {
+	for _, term := range strings.Fields(q) {
+		if k, v, ok := strings.Cut(term, ":"); ok && k == "name" {
+			return v
+		}
+	}
+	if strings.Contains(q, ":") {
+		return ""
+	}
+	return q
+}
+
+// serveRRSet creates, replaces, patches or deletes a single RRSet
+func (s *Server) serveRRSet(w http.ResponseWriter, r *http.Request, z *zone, id rrsetID) {
+	var rr udnssdk.RRSet
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(&rr); err != nil {
+			writeError(w, badRequest("Invalid json: %v", err))
+			return
+		}
+		if len(rr.RData) == 0 {
+			writeError(w, badRequest("rdata is required"))
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := z.rrsets[id]; ok {
+				return 0, nil, &apiError{http.StatusBadRequest, udnssdk.ErrorCodeRRSetAlreadyExists,
+					fmt.Sprintf("Resource Record of type %d with these attributes already exists in the system.", rrTypeCodes[id.typ])}
+			}
+			z.rrsets[id] = storedRRSet(id, rr)
+			return http.StatusCreated, map[string]string{"message": "Successful"}, nil
+		})
+	case http.MethodPut:
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := z.rrsets[id]; !ok {
+				return 0, nil, rrsetNotFound()
+			}
+			z.rrsets[id] = storedRRSet(id, rr)
+			return http.StatusOK, map[string]string{"message": "Successful"}, nil
+		})
+	case http.MethodPatch:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, badRequest("%v", err))
+			return
+		}
+		s.write(w, func() (int, interface{}, *apiError) {
+			old, ok := z.rrsets[id]
+			if !ok {
+				return 0, nil, rrsetNotFound()
+			}
+			patched, err := patchRRSet(old, body)
+			if err != nil {
+				return 0, nil, err
+			}
+			z.rrsets[id] = storedRRSet(id, patched)
+			return http.StatusOK, map[string]string{"message": "Successful"}, nil
+		})
+	case http.MethodDelete:
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := z.rrsets[id]; !ok {
+				return 0, nil, rrsetNotFound()
+			}
+			delete(z.rrsets, id)
+			return http.StatusNoContent, nil, nil
+		})
+	default:
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+	}
+}
+
+func rrsetNotFound() *apiError {
+	return &apiError{http.StatusNotFound, udnssdk.ErrorCodeRRSetNotFound,
+		"Cannot find resource record data for the input zone, record type and owner combination."}
+}
+
+// serveProbes implements the probes of a pool record
+func (s *Server) serveProbes(w http.ResponseWriter, r *http.Request, z *zone, owner string, seg []string) {
+	probes := z.probes[owner]
+	if probes == nil {
+		probes = map[string]udnssdk.ProbeInfoDTO{}
+		z.probes[owner] = probes
+	}
+
+	if len(seg) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			list := []udnssdk.ProbeInfoDTO{}
+			for _, id := range sortedKeys(probes) {
+				list = append(list, probes[id])
+			}
+			writeJSON(w, http.StatusOK, udnssdk.ProbeListDTO{
+				Probes:     list,
+				Queryinfo:  queryInfo(r),
+				Resultinfo: udnssdk.ResultInfo{TotalCount: len(list), ReturnedCount: len(list)},
+			})
+		case http.MethodPost:
+			var p udnssdk.ProbeInfoDTO
+			if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+				writeError(w, badRequest("Invalid json: %v", err))
+				return
+			}
+			s.write(w, func() (int, interface{}, *apiError) {
+				p.ID = s.id("probe")
+				p.PoolRecord = owner
+				probes[p.ID] = p
+				return http.StatusCreated, p, nil
+			})
+		default:
+			writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+		}
+		return
+	}
+
+	id := seg[0]
+	switch r.Method {
+	case http.MethodGet:
+		p, ok := probes[id]
+		if !ok {
+			writeError(w, notFound("probe "+id))
+			return
+		}
+		writeJSON(w, http.StatusOK, p)
+	case http.MethodPut:
+		var p udnssdk.ProbeInfoDTO
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			writeError(w, badRequest("Invalid json: %v", err))
+			return
+		}
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := probes[id]; !ok {
+				return 0, nil, notFound("probe " + id)
+			}
+			p.ID = id
+			p.PoolRecord = owner
+			probes[id] = p
+			return http.StatusOK, map[string]string{"message": "Successful"}, nil
+		})
+	case http.MethodDelete:
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := probes[id]; !ok {
+				return 0, nil, notFound("probe " + id)
+			}
+			delete(probes, id)
+			return http.StatusNoContent, nil, nil
+		})
+	default:
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+	}
+}
+
+// serveEvents implements the scheduled events of a pool record
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request, z *zone, owner string, seg []string) {
+	events := z.events[owner]
+	if events == nil {
+		events = map[string]udnssdk.EventInfoDTO{}
+		z.events[owner] = events
+	}
+
+	if len(seg) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			list := []udnssdk.EventInfoDTO{}
+			for _, id := range sortedKeys(events) {
+				list = append(list, events[id])
+			}
+			page, ri := paginate(list, r, s.PageSize)
+			writeJSON(w, http.StatusOK, udnssdk.EventInfoListDTO{Events: page, Queryinfo: queryInfo(r), Resultinfo: ri})
+		case http.MethodPost:
+			var e udnssdk.EventInfoDTO
+			if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+				writeError(w, badRequest("Invalid json: %v", err))
+				return
+			}
+			s.write(w, func() (int, interface{}, *apiError) {
+				e.ID = s.id("event")
+				e.PoolRecord = owner
+				events[e.ID] = e
+				return http.StatusCreated, e, nil
+			})
+		default:
+			writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+		}
+		return
+	}
+
+	id := seg[0]
+	switch r.Method {
+	case http.MethodGet:
+		e, ok := events[id]
+		if !ok {
+			writeError(w, notFound("event "+id))
+			return
+		}
+		writeJSON(w, http.StatusOK, e)
+	case http.MethodPut:
+		var e udnssdk.EventInfoDTO
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			writeError(w, badRequest("Invalid json: %v", err))
+			return
+		}
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := events[id]; !ok {
+				return 0, nil, notFound("event " + id)
+			}
+			e.ID = id
+			e.PoolRecord = owner
+			events[id] = e
+			return http.StatusOK, map[string]string{"message": "Successful"}, nil
+		})
+	case http.MethodDelete:
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := events[id]; !ok {
+				return 0, nil, notFound("event " + id)
+			}
+			delete(events, id)
+			return http.StatusNoContent, nil, nil
+		})
+	default:
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+	}
+}
+
+// serveNotifications implements the notification subscriptions of a pool record, keyed by email
+func (s *Server) serveNotifications(w http.ResponseWriter, r *http.Request, z *zone, owner string, seg []string) {
+	notifications := z.notifications[owner]
+	if notifications == nil {
+		notifications = map[string]udnssdk.NotificationDTO{}
+		z.notifications[owner] = notifications
+	}
+
+	if len(seg) == 0 {
+		if r.Method != http.MethodGet {
+			writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+			return
+		}
+		list := []udnssdk.NotificationDTO{}
+		for _, email := range sortedKeys(notifications) {
+			list = append(list, notifications[email])
+		}
+		page, ri := paginate(list, r, s.PageSize)
+		writeJSON(w, http.StatusOK, udnssdk.NotificationListDTO{Notifications: page, Queryinfo: queryInfo(r), Resultinfo: ri})
+		return
+	}
+
+	email := seg[0]
+	var n udnssdk.NotificationDTO
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+			writeError(w, badRequest("Invalid json: %v", err))
+			return
+		}
+		n.Email = email
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		n, ok := notifications[email]
+		if !ok {
+			writeError(w, notFound("notification "+email))
+			return
+		}
+		writeJSON(w, http.StatusOK, n)
+	case http.MethodPost:
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := notifications[email]; ok {
+				return 0, nil, badRequest("Notification for %s already exists.", email)
+			}
+			notifications[email] = n
+			return http.StatusCreated, n, nil
+		})
+	case http.MethodPut:
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := notifications[email]; !ok {
+				return 0, nil, notFound("notification " + email)
+			}
+			notifications[email] = n
+			return http.StatusOK, map[string]string{"message": "Successful"}, nil
+		})
+	case http.MethodDelete:
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := notifications[email]; !ok {
+				return 0, nil, notFound("notification " + email)
+			}
+			delete(notifications, email)
+			return http.StatusNoContent, nil, nil
+		})
+	default:
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+	}
+}
+
+// serveAlerts lists the probe alerts of a pool record; alerts are seeded with AddAlert
+func (s *Server) serveAlerts(w http.ResponseWriter, r *http.Request, z *zone, owner string, seg []string) {
+	if len(seg) != 0 || r.Method != http.MethodGet {
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+		return
+	}
+	page, ri := paginate(append([]udnssdk.ProbeAlertDataDTO{}, z.alerts[owner]...), r, s.PageSize)
+	writeJSON(w, http.StatusOK, udnssdk.ProbeAlertDataListDTO{Alerts: page, Queryinfo: queryInfo(r), Resultinfo: ri})
+}
+
+// serveGeoGroups implements the account-level geo directional groups
+func (s *Server) serveGeoGroups(w http.ResponseWriter, r *http.Request, seg []string) {
+	if len(seg) == 0 {
+		if r.Method != http.MethodGet {
+			writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+			return
+		}
+		q := nameTerm(queryInfo(r).Q)
+		list := []udnssdk.AccountLevelGeoDirectionalGroupDTO{}
+		for _, name := range sortedKeys(s.geos) {
+			if strings.Contains(name, q) {
+				list = append(list, s.geos[name])
+			}
+		}
+		page, ri := paginate(list, r, s.PageSize)
+		writeJSON(w, http.StatusOK, udnssdk.AccountLevelGeoDirectionalGroupListDTO{
+			AccountName: s.Account, GeoGroups: page, Queryinfo: queryInfo(r), Resultinfo: ri,
+		})
+		return
+	}
+
+	name := seg[0]
+	var g udnssdk.AccountLevelGeoDirectionalGroupDTO
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(&g); err != nil {
+			writeError(w, badRequest("Invalid json: %v", err))
+			return
+		}
+		g.Name = name
+	}
+	s.serveGroup(w, r, name, "geo",
+		func() (interface{}, bool) { g, ok := s.geos[name]; return g, ok },
+		func() { s.geos[name] = g },
+		func() { delete(s.geos, name) })
+}
+
+// serveIPGroups implements the account-level IP directional groups
+func (s *Server) serveIPGroups(w http.ResponseWriter, r *http.Request, seg []string) {
+	if len(seg) == 0 {
+		if r.Method != http.MethodGet {
+			writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+			return
+		}
+		q := nameTerm(queryInfo(r).Q)
+		list := []udnssdk.AccountLevelIPDirectionalGroupDTO{}
+		for _, name := range sortedKeys(s.ips) {
+			if strings.Contains(name, q) {
+				list = append(list, s.ips[name])
+			}
+		}
+		page, ri := paginate(list, r, s.PageSize)
+		writeJSON(w, http.StatusOK, udnssdk.AccountLevelIPDirectionalGroupListDTO{
+			AccountName: s.Account, IPGroups: page, Queryinfo: queryInfo(r), Resultinfo: ri,
+		})
+		return
+	}
+
+	name := seg[0]
+	var g udnssdk.AccountLevelIPDirectionalGroupDTO
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(&g); err != nil {
+			writeError(w, badRequest("Invalid json: %v", err))
+			return
+		}
+		g.Name = name
+	}
+	s.serveGroup(w, r, name, "ip",
+		func() (interface{}, bool) { g, ok := s.ips[name]; return g, ok },
+		func() { s.ips[name] = g },
+		func() { delete(s.ips, name) })
+}
+
+// serveGroup implements reading and writing a single directional group of either kind
+func (s *Server) serveGroup(w http.ResponseWriter, r *http.Request, name, kind string,
+	get func() (interface{}, bool), put func(), del func()) {
+	what := fmt.Sprintf("%s group %s", kind, name)
+	switch r.Method {
+	case http.MethodGet:
+		g, ok := get()
+		if !ok {
+			writeError(w, notFound(what))
+			return
+		}
+		writeJSON(w, http.StatusOK, g)
+	case http.MethodPost:
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := get(); ok {
+				return 0, nil, &apiError{http.StatusConflict, 0, fmt.Sprintf("The %s already exists.", what)}
+			}
+			put()
+			return http.StatusCreated, map[string]string{"message": "Successful"}, nil
+		})
+	case http.MethodPut:
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := get(); !ok {
+				return 0, nil, notFound(what)
+			}
+			put()
+			return http.StatusOK, map[string]string{"message": "Successful"}, nil
+		})
+	case http.MethodDelete:
+		s.write(w, func() (int, interface{}, *apiError) {
+			if _, ok := get(); !ok {
+				return 0, nil, notFound(what)
+			}
+			del()
+			return http.StatusNoContent, nil, nil
+		})
+	default:
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+	}
+}
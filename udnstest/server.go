@@ -0,0 +1,539 @@
+// Package udnstest provides an in-memory fake of the UltraDNS REST API for
+// testing code built on udnssdk without network access or a live account.
+//
+// The fake is stateful: records, probes, events, notifications and directional
+// groups created through the API can be read back, updated and deleted.
+// It implements the token endpoint, offset pagination with ResultInfo, UltraDNS
+// error bodies, the batch endpoint and, when Async is set, deferred tasks answered
+// with 202 Accepted.
+//
+//	srv := udnstest.NewServer()
+//	defer srv.Close()
+//	srv.AddZone("example.com.")
+//	client, err := srv.Client()
+package udnstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/terra-farm/udnssdk"
+)
+
+// Server is a fake UltraDNS REST API backed by an httptest.Server
+type Server struct {
+	*httptest.Server
+
+	// Username and Password are the only credentials the token endpoint accepts
+	Username string
+	Password string
+	// Account is the name of the single account the fake serves
+	Account string
+	// PageSize is the number of results returned per page when no limit is requested
+	PageSize int
+	// Async makes writes return 202 Accepted with an X-Task-Id header, applying them once the task completes
+	Async bool
+	// TaskPolls is the number of polls after which a deferred task completes
+	TaskPolls int
+
+	mu     sync.Mutex
+	nextID int
+	tokens map[string]bool
+	zones  map[string]*zone
+	geos   map[string]udnssdk.AccountLevelGeoDirectionalGroupDTO
+	ips    map[string]udnssdk.AccountLevelIPDirectionalGroupDTO
+	tasks  map[string]*task
+	order  []string // task IDs in creation order
+	faults []*fault
+}
+
+// zone holds the resources of a single zone
+type zone struct {
+	name          string
+	info          udnssdk.Zone
+	rrsets        map[rrsetID]udnssdk.RRSet
+	probes        map[string]map[string]udnssdk.ProbeInfoDTO // owner -> id -> probe
+	events        map[string]map[string]udnssdk.EventInfoDTO // owner -> id -> event
+	notifications map[string]map[string]udnssdk.NotificationDTO
+	alerts        map[string][]udnssdk.ProbeAlertDataDTO
+}
+
+// rrsetID identifies an RRSet within a zone by absolute owner name and canonical type
+type rrsetID struct {
+	owner string
+	typ   string
+}
+
+// task is a deferred write
+type task struct {
+	udnssdk.Task
+	polls  int
+	op     func() (int, interface{}, *apiError)
+	result []byte
+}
+
+// fault is an injected failure
+type fault struct {
+	method string
+	prefix string
+	left   int
+	err    *apiError
+}
+
+// apiError is an UltraDNS error body with its HTTP status
+type apiError struct {
+	status  int
+	code    int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d: %s", e.code, e.message)
+}
+
+func notFound(what string) *apiError {
+	return &apiError{http.StatusNotFound, udnssdk.ErrorCodeDataNotFound, fmt.Sprintf("Data not found: %s", what)}
+}
+
+func badRequest(format string, v ...interface{}) *apiError {
+	return &apiError{http.StatusBadRequest, 49001, fmt.Sprintf(format, v...)}
+}
+
+// NewServer starts a fake UltraDNS API. Callers should Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		Username:  "udnstest",
+		Password:  "udnstest",
+		Account:   "udnstest",
+		PageSize:  100,
+		TaskPolls: 1,
+		tokens:    map[string]bool{},
+		zones:     map[string]*zone{},
+		geos:      map[string]udnssdk.AccountLevelGeoDirectionalGroupDTO{},
+		ips:       map[string]udnssdk.AccountLevelIPDirectionalGroupDTO{},
+		tasks:     map[string]*task{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a udnssdk.Client authenticated against the fake.
+// It polls tasks every millisecond and retries with millisecond backoff;
+// opts are applied after these defaults.
+func (s *Server) Client(opts ...udnssdk.Option) (*udnssdk.Client, error) {
+	defaults := []udnssdk.Option{
+		udnssdk.WithCredentials(s.Username, s.Password),
+		udnssdk.WithBaseURL(s.URL),
+		udnssdk.WithTaskWaiter(udnssdk.TaskWaiter{PollInterval: time.Millisecond, Timeout: 10 * time.Second}),
+		udnssdk.WithRetryPolicy(udnssdk.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}),
+	}
+	return udnssdk.NewClientWithOptions(append(defaults, opts...)...)
+}
+
+// AddZone creates an empty primary zone in the server's account
+func (s *Server) AddZone(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addZone(name)
+}
+
+func (s *Server) addZone(name string) *zone {
+	return s.addZoneInfo(udnssdk.NewPrimaryZone(s.Account, name))
+}
+
+func (s *Server) addZoneInfo(info udnssdk.Zone) *zone {
+	name := canonicalZone(info.Properties.Name)
+	info.Properties.Name = name
+	z := &zone{
+		name:          name,
+		info:          info,
+		rrsets:        map[rrsetID]udnssdk.RRSet{},
+		probes:        map[string]map[string]udnssdk.ProbeInfoDTO{},
+		events:        map[string]map[string]udnssdk.EventInfoDTO{},
+		notifications: map[string]map[string]udnssdk.NotificationDTO{},
+		alerts:        map[string][]udnssdk.ProbeAlertDataDTO{},
+	}
+	s.zones[name] = z
+	return z
+}
+
+// PutRRSet stores rr in the zone, creating the zone if necessary and replacing any RRSet of the same owner and type
+func (s *Server) PutRRSet(zoneName string, rr udnssdk.RRSet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	z, ok := s.zones[canonicalZone(zoneName)]
+	if !ok {
+		z = s.addZone(zoneName)
+	}
+	id := rrsetID{absoluteName(rr.OwnerName, z.name), canonicalType(rr.RRType)}
+	z.rrsets[id] = storedRRSet(id, rr)
+}
+
+// RRSets returns a snapshot of the zone's RRSets, sorted by owner and type, as the API would return them
+func (s *Server) RRSets(zoneName string) []udnssdk.RRSet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	z, ok := s.zones[canonicalZone(zoneName)]
+	if !ok {
+		return nil
+	}
+	return z.sortedRRSets(func(rrsetID) bool { return true })
+}
+
+// AddAlert records a probe alert for the A record at owner
+func (s *Server) AddAlert(zoneName, owner string, a udnssdk.ProbeAlertDataDTO) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	z, ok := s.zones[canonicalZone(zoneName)]
+	if !ok {
+		z = s.addZone(zoneName)
+	}
+	owner = absoluteName(owner, z.name)
+	z.alerts[owner] = append(z.alerts[owner], a)
+}
+
+// Tasks returns a snapshot of all deferred tasks in creation order
+func (s *Server) Tasks() []udnssdk.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ts := make([]udnssdk.Task, 0, len(s.order))
+	for _, id := range s.order {
+		if t, ok := s.tasks[id]; ok {
+			ts = append(ts, t.Task)
+		}
+	}
+	return ts
+}
+
+// Fail makes the next n requests with the given method whose path, relative to /v1/, starts with prefix
+// fail with status and an UltraDNS error body. An empty method matches any method.
+func (s *Server) Fail(method, prefix string, n, status, code int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{method, prefix, n, &apiError{status, code, message}})
+}
+
+func (s *Server) id(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s%08d", prefix, s.nextID)
+}
+
+// serveHTTP authenticates, applies injected faults and routes a request
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if !strings.HasPrefix(path, "v1/") {
+		writeError(w, notFound(r.URL.Path))
+		return
+	}
+	path = strings.TrimSuffix(strings.TrimPrefix(path, "v1/"), "/")
+
+	for _, f := range s.faults {
+		if f.left > 0 && (f.method == "" || f.method == r.Method) && strings.HasPrefix(path, f.prefix) {
+			f.left--
+			writeError(w, f.err)
+			return
+		}
+	}
+
+	if path == "authorization/token" {
+		s.serveToken(w, r)
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, &apiError{http.StatusUnauthorized, 60001, "invalid_token: Invalid or expired access token."})
+		return
+	}
+
+	s.route(w, r, path)
+}
+
+// route dispatches an authorized request for path, relative to the API version
+func (s *Server) route(w http.ResponseWriter, r *http.Request, path string) {
+	seg := strings.Split(path, "/")
+	switch seg[0] {
+	case "batch":
+		s.serveBatch(w, r)
+	case "accounts":
+		s.serveAccounts(w, r, seg[1:])
+	case "zones":
+		s.serveZones(w, r, seg[1:])
+	case "tasks":
+		s.serveTasks(w, r, seg[1:])
+	default:
+		writeError(w, notFound(path))
+	}
+}
+
+// serveToken implements the password credentials grant
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+		return
+	}
+	r.ParseForm()
+	if r.PostForm.Get("grant_type") != "password" ||
+		r.PostForm.Get("username") != s.Username || r.PostForm.Get("password") != s.Password {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"errorCode":         udnssdk.ErrorCodeInvalidGrant,
+			"errorMessage":      "invalid_grant:Invalid username & password combination.",
+			"error":             "invalid_grant",
+			"error_description": "60001: invalid_grant:Invalid username & password combination.",
+		})
+		return
+	}
+	tok := s.id("token")
+	s.tokens[tok] = true
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"tokenType":    "Bearer",
+		"token_type":   "Bearer",
+		"accessToken":  tok,
+		"access_token": tok,
+		"expiresIn":    3600,
+		"expires_in":   3600,
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	h := r.Header.Get("Authorization")
+	return strings.HasPrefix(h, "Bearer ") && s.tokens[strings.TrimPrefix(h, "Bearer ")]
+}
+
+// write applies op directly, or as a deferred task if the server is Async
+func (s *Server) write(w http.ResponseWriter, op func() (int, interface{}, *apiError)) {
+	if !s.Async {
+		status, body, err := op()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, status, body)
+		return
+	}
+	s.deferTask(w, op)
+}
+
+// deferTask answers 202 Accepted with the ID of a new task that runs op once it completes
+func (s *Server) deferTask(w http.ResponseWriter, op func() (int, interface{}, *apiError)) {
+	id := s.id("task")
+	s.tasks[id] = &task{
+		Task: udnssdk.Task{TaskID: id, TaskStatusCode: "PENDING", Message: "Pending"},
+		op:   op,
+	}
+	s.order = append(s.order, id)
+	w.Header().Set("X-Task-Id", id)
+	writeJSON(w, http.StatusAccepted, map[string]string{"message": "Pending"})
+}
+
+// serveTasks implements the tasks collection, polling and results
+func (s *Server) serveTasks(w http.ResponseWriter, r *http.Request, seg []string) {
+	if len(seg) == 0 || seg[0] == "" {
+		if r.Method != http.MethodGet {
+			writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+			return
+		}
+		ts := []udnssdk.Task{}
+		for _, id := range s.order {
+			if t, ok := s.tasks[id]; ok {
+				ts = append(ts, t.Task)
+			}
+		}
+		items, ri := paginate(ts, r, s.PageSize)
+		writeJSON(w, http.StatusOK, udnssdk.TaskListDTO{Tasks: items, Resultinfo: ri})
+		return
+	}
+
+	t, ok := s.tasks[seg[0]]
+	if !ok {
+		writeError(w, notFound("task "+seg[0]))
+		return
+	}
+	switch {
+	case len(seg) == 2 && seg[1] == "result" && r.Method == http.MethodGet:
+		if t.TaskStatusCode != "COMPLETE" {
+			writeError(w, notFound("result of task "+t.TaskID))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(t.result)
+	case len(seg) == 1 && r.Method == http.MethodGet:
+		if t.op != nil {
+			t.polls++
+			if t.polls >= s.TaskPolls {
+				s.complete(t)
+			} else {
+				t.TaskStatusCode = "IN_PROCESS"
+			}
+		}
+		writeJSON(w, http.StatusOK, t.Task)
+	case len(seg) == 1 && r.Method == http.MethodDelete:
+		delete(s.tasks, t.TaskID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+	}
+}
+
+// complete runs a task's deferred write
+func (s *Server) complete(t *task) {
+	_, body, err := t.op()
+	t.op = nil
+	if err != nil {
+		t.TaskStatusCode = "ERROR"
+		t.Message = err.message
+		return
+	}
+	t.TaskStatusCode = "COMPLETE"
+	t.Message = "Processing complete"
+	t.ResultURI = fmt.Sprintf("tasks/%s/result", t.TaskID)
+	t.result, _ = json.Marshal(body)
+}
+
+// serveAccounts implements accounts and account-level directional groups
+func (s *Server) serveAccounts(w http.ResponseWriter, r *http.Request, seg []string) {
+	account := udnssdk.Account{
+		AccountName:           s.Account,
+		AccountHolderUserName: s.Username,
+		OwnerUserName:         s.Username,
+		NumberOfUsers:         1,
+		NumberOfGroups:        1,
+		AccountType:           "ORGANIZATION",
+	}
+	switch {
+	case len(seg) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, udnssdk.AccountListDTO{
+			Accounts:   []udnssdk.Account{account},
+			Resultinfo: udnssdk.ResultInfo{TotalCount: 1, ReturnedCount: 1},
+		})
+	case len(seg) >= 1 && seg[0] != s.Account:
+		writeError(w, notFound("account "+seg[0]))
+	case len(seg) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, account)
+	case len(seg) >= 3 && seg[1] == "dirgroups" && seg[2] == "geo":
+		s.serveGeoGroups(w, r, seg[3:])
+	case len(seg) >= 3 && seg[1] == "dirgroups" && seg[2] == "ip":
+		s.serveIPGroups(w, r, seg[3:])
+	default:
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+	}
+}
+
+// serveBatch implements the batch endpoint. Like UltraDNS, it always answers with a deferred
+// task, whose result lists the response to each request once the task completes.
+func (s *Server) serveBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, &apiError{http.StatusMethodNotAllowed, 0, "Method not allowed."})
+		return
+	}
+	var reqs []udnssdk.BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+		writeError(w, badRequest("%v", err))
+		return
+	}
+	if len(reqs) > udnssdk.MaxBatchSize {
+		writeError(w, badRequest("A batch holds at most %d requests.", udnssdk.MaxBatchSize))
+		return
+	}
+
+	s.deferTask(w, func() (int, interface{}, *apiError) {
+		async := s.Async
+		s.Async = false
+		defer func() { s.Async = async }()
+
+		resps := make([]udnssdk.BatchResponse, len(reqs))
+		for i, br := range reqs {
+			body := []byte{}
+			if br.Body != nil {
+				body, _ = json.Marshal(br.Body)
+			}
+			sub := httptest.NewRequest(br.Method, br.URI, bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+			path := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(sub.URL.Path, "/"), "v1/"), "/")
+			s.route(rec, sub, path)
+			resps[i] = udnssdk.BatchResponse{Code: rec.Code, Body: bytes.TrimSpace(rec.Body.Bytes())}
+		}
+		return http.StatusOK, resps, nil
+	})
+}
+
+// paginate returns the page of items selected by the offset and limit query parameters
+func paginate[T any](items []T, r *http.Request, pageSize int) ([]T, udnssdk.ResultInfo) {
+	q := r.URL.Query()
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit <= 0 || limit > pageSize {
+		limit = pageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(items) {
+		offset = len(items)
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	page := append([]T{}, items[offset:end]...)
+	return page, udnssdk.ResultInfo{
+		TotalCount:    len(items),
+		Offset:        offset,
+		ReturnedCount: len(page),
+	}
+}
+
+// queryInfo echoes the query parameters of a list request
+func queryInfo(r *http.Request) udnssdk.QueryInfo {
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	query := q.Get("q")
+	if query == "" {
+		query = q.Get("query")
+	}
+	return udnssdk.QueryInfo{
+		Q:       query,
+		Sort:    q.Get("sort"),
+		Reverse: q.Get("reverse") == "true",
+		Limit:   limit,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	if v == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes err as an UltraDNS error list body
+func writeError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.status, []map[string]interface{}{{
+		"errorCode":    err.code,
+		"errorMessage": err.message,
+	}})
+}
+
+// sortedKeys returns the keys of m in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,456 @@
+package udnstest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/terra-farm/udnssdk"
+)
+
+func newTestClient(t *testing.T, s *Server, opts ...udnssdk.Option) *udnssdk.Client {
+	c, err := s.Client(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func Test_RRSets_CRUD(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.AddZone("example.com")
+	c := newTestClient(t, s)
+	k := udnssdk.RRSetKey{Zone: "example.com.", Type: "A", Name: "www"}
+
+	if _, err := c.RRSets.Create(k, udnssdk.RRSet{TTL: 300, RData: []string{"192.0.2.1"}}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := c.RRSets.Create(k, udnssdk.RRSet{RData: []string{"192.0.2.1"}}); !udnssdk.IsAlreadyExists(err) {
+		t.Errorf("second Create: %v, want ErrAlreadyExists", err)
+	}
+
+	rrsets, err := c.RRSets.Select(k)
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	want := []udnssdk.RRSet{{OwnerName: "www.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"192.0.2.1"}}}
+	if !reflect.DeepEqual(rrsets, want) {
+		t.Errorf("Select: %+v, want: %+v", rrsets, want)
+	}
+
+	if _, err := c.RRSets.Update(k, udnssdk.RRSet{TTL: 60, RData: []string{"192.0.2.2"}}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got := s.RRSets("example.com."); len(got) != 1 || got[0].TTL != 60 || got[0].RData[0] != "192.0.2.2" {
+		t.Errorf("after Update: %+v", got)
+	}
+
+	if _, err := c.RRSets.Delete(k); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := c.RRSets.Select(k); !udnssdk.IsNotFound(err) {
+		t.Errorf("Select after Delete: %v, want ErrNotFound", err)
+	}
+	if _, err := c.RRSets.Delete(k); !udnssdk.IsNotFound(err) {
+		t.Errorf("second Delete: %v, want ErrNotFound", err)
+	}
+}
+
+func Test_RRSets_Pagination(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.PageSize = 2
+	for i := 0; i < 5; i++ {
+		s.PutRRSet("example.com.", udnssdk.RRSet{OwnerName: fmt.Sprintf("host%d", i), RRType: "A", RData: []string{"192.0.2.1"}})
+	}
+	s.PutRRSet("example.com.", udnssdk.RRSet{OwnerName: "example.com.", RRType: "MX", RData: []string{"10 mail.example.com."}})
+	c := newTestClient(t, s)
+
+	all, err := c.RRSets.Select(udnssdk.RRSetKey{Zone: "example.com."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 6 {
+		t.Errorf("len(Select): %d, want: %d", len(all), 6)
+	}
+
+	page, ri, _, err := c.RRSets.SelectWithOffset(udnssdk.RRSetKey{Zone: "example.com.", Type: "A"}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 1 || ri.TotalCount != 5 || ri.Offset != 4 || ri.ReturnedCount != 1 {
+		t.Errorf("SelectWithOffset: %d rrsets, %+v", len(page), ri)
+	}
+}
+
+func Test_Async_Writes(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.Async = true
+	s.TaskPolls = 3
+	s.AddZone("example.com.")
+	c := newTestClient(t, s)
+	k := udnssdk.RRSetKey{Zone: "example.com.", Type: "TXT", Name: "note"}
+
+	if _, err := c.RRSets.Create(k, udnssdk.RRSet{RData: []string{"hello"}}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if len(s.RRSets("example.com.")) != 1 {
+		t.Errorf("RRSet was not created once the task completed")
+	}
+
+	_, err := c.RRSets.Create(k, udnssdk.RRSet{RData: []string{"hello"}})
+	var tf *udnssdk.TaskFailedError
+	if !errors.As(err, &tf) {
+		t.Errorf("duplicate Create: %v, want TaskFailedError", err)
+	}
+
+	ts := s.Tasks()
+	if len(ts) != 2 || ts[0].TaskStatusCode != "COMPLETE" || ts[1].TaskStatusCode != "ERROR" {
+		t.Errorf("Tasks: %+v", ts)
+	}
+}
+
+func Test_Async_Deferred(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.Async = true
+	s.AddZone("example.com.")
+	c := newTestClient(t, s, udnssdk.WithTaskWaiter(udnssdk.TaskWaiter{PollInterval: time.Millisecond, Async: true}))
+
+	if _, err := c.RRSets.Create(udnssdk.RRSetKey{Zone: "example.com.", Type: "A", Name: "www"}, udnssdk.RRSet{RData: []string{"192.0.2.1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.RRSets("example.com.")) != 0 {
+		t.Errorf("write was applied before its task completed")
+	}
+	ts := s.Tasks()
+	if len(ts) != 1 {
+		t.Fatalf("Tasks: %+v", ts)
+	}
+	if _, err := c.Tasks.Wait(udnssdk.TaskID(ts[0].TaskID)); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.RRSets("example.com.")) != 1 {
+		t.Errorf("write was not applied when its task completed")
+	}
+}
+
+func Test_Probes_Events_Notifications(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.PutRRSet("example.com.", udnssdk.RRSet{OwnerName: "pool", RRType: "A", RData: []string{"192.0.2.1", "192.0.2.2"}})
+	c := newTestClient(t, s)
+	rk := udnssdk.RRSetKey{Zone: "example.com.", Type: "A", Name: "pool"}
+
+	p := udnssdk.ProbeInfoDTO{
+		ProbeType: udnssdk.PingProbeType,
+		Interval:  "ONE_MINUTE",
+		Agents:    []string{"NEW_YORK", "DALLAS"},
+		Threshold: 2,
+		Details: &udnssdk.ProbeDetailsDTO{
+			Detail: udnssdk.PingProbeDetailsDTO{Packets: 3, Limits: map[string]udnssdk.ProbeDetailsLimitDTO{"lossPercent": {Warning: 1, Critical: 2, Fail: 3}}},
+		},
+	}
+	if _, err := c.Probes.Create(rk, p); err != nil {
+		t.Fatalf("Probes.Create: %v", err)
+	}
+	probes, _, err := c.Probes.Select(rk, "")
+	if err != nil || len(probes) != 1 {
+		t.Fatalf("Probes.Select: %v, %v", probes, err)
+	}
+	got, _, err := c.Probes.Find(udnssdk.ProbeKey{Zone: rk.Zone, Name: rk.Name, ID: probes[0].ID})
+	if err != nil {
+		t.Fatalf("Probes.Find: %v", err)
+	}
+	if got.Threshold != 2 || got.PoolRecord != "pool.example.com." {
+		t.Errorf("Probes.Find: %+v", got)
+	}
+
+	if _, err := c.Events.Create(rk, udnssdk.EventInfoDTO{EventType: "PING", Repeat: "DAILY"}); err != nil {
+		t.Fatalf("Events.Create: %v", err)
+	}
+	events, err := c.Events.Select(rk, "")
+	if err != nil || len(events) != 1 || events[0].ID == "" {
+		t.Errorf("Events.Select: %+v, %v", events, err)
+	}
+
+	nk := udnssdk.NotificationKey{Zone: rk.Zone, Type: rk.Type, Name: rk.Name, Email: "ops@example.com"}
+	if _, err := c.Notifications.Create(nk, udnssdk.NotificationDTO{PoolRecords: []udnssdk.NotificationPoolRecord{{PoolRecord: "192.0.2.1"}}}); err != nil {
+		t.Fatalf("Notifications.Create: %v", err)
+	}
+	n, _, err := c.Notifications.Find(nk)
+	if err != nil || n.Email != "ops@example.com" {
+		t.Errorf("Notifications.Find: %+v, %v", n, err)
+	}
+	if _, err := c.Notifications.Delete(nk); err != nil {
+		t.Errorf("Notifications.Delete: %v", err)
+	}
+	if _, _, err := c.Notifications.Find(nk); !udnssdk.IsNotFound(err) {
+		t.Errorf("Notifications.Find after Delete: %v, want ErrNotFound", err)
+	}
+
+	s.AddAlert("example.com.", "pool", udnssdk.ProbeAlertDataDTO{PoolRecord: "192.0.2.1", Status: "FAIL"})
+	alerts, err := c.Alerts.Select(rk)
+	if err != nil || len(alerts) != 1 {
+		t.Errorf("Alerts.Select: %+v, %v", alerts, err)
+	}
+}
+
+func Test_DirectionalGroups(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(t, s)
+	k := udnssdk.GeoDirectionalPoolKey{Account: udnssdk.AccountKey(s.Account), Name: "europe"}
+
+	if _, err := c.DirectionalPools.Geos().Create(k, udnssdk.AccountLevelGeoDirectionalGroupDTO{Codes: []string{"EUR"}}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	g, _, err := c.DirectionalPools.Geos().Find(k)
+	if err != nil || g.Name != "europe" || len(g.Codes) != 1 {
+		t.Errorf("Find: %+v, %v", g, err)
+	}
+	groups, err := c.DirectionalPools.Geos().Select(udnssdk.GeoDirectionalPoolKey{Account: k.Account}, "")
+	if err != nil || len(groups) != 1 {
+		t.Errorf("Select: %+v, %v", groups, err)
+	}
+
+	ik := udnssdk.IPDirectionalPoolKey{Account: udnssdk.AccountKey(s.Account), Name: "office"}
+	if _, _, err := c.DirectionalPools.IPs().Find(ik); !udnssdk.IsNotFound(err) {
+		t.Errorf("IPs().Find: %v, want ErrNotFound", err)
+	}
+}
+
+func Test_Authentication(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(t, s, udnssdk.WithCredentials(s.Username, "wrong"))
+
+	if _, _, err := c.Accounts.Select(); !udnssdk.IsUnauthorized(err) {
+		t.Errorf("Accounts.Select: %v, want ErrUnauthorized", err)
+	}
+}
+
+func Test_Fail(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(t, s)
+
+	s.Fail("GET", "accounts", 2, 503, 0, "Service unavailable.")
+	accounts, _, err := c.Accounts.Select()
+	if err != nil || len(accounts) != 1 || accounts[0].AccountName != s.Account {
+		t.Errorf("Accounts.Select after transient failures: %+v, %v", accounts, err)
+	}
+
+	s.Fail("", "accounts", 1, 429, 0, "Too many requests.")
+	if _, _, err := c.Accounts.Select(); err != nil {
+		t.Errorf("Accounts.Select after rate limit: %v", err)
+	}
+
+	s.Fail("GET", "accounts", 3, 503, 0, "Service unavailable.")
+	if _, _, err := c.Accounts.Select(); err == nil {
+		t.Errorf("Accounts.Select succeeded after retries were exhausted")
+	}
+}
+
+func Test_Zones(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(t, s)
+
+	for _, z := range []udnssdk.Zone{
+		udnssdk.NewPrimaryZone(s.Account, "example.com."),
+		udnssdk.NewSecondaryZone(s.Account, "example.net.", udnssdk.NameServerIP{IP: "192.0.2.53"}),
+		udnssdk.NewAliasZone(s.Account, "example.org.", "example.com."),
+	} {
+		if _, err := c.Zones.Create(z); err != nil {
+			t.Fatalf("Create(%s): %v", z.Properties.Name, err)
+		}
+	}
+	if _, err := c.Zones.Create(udnssdk.NewPrimaryZone(s.Account, "example.com.")); !udnssdk.IsAlreadyExists(err) {
+		t.Errorf("duplicate Create: %v, want ErrAlreadyExists", err)
+	}
+	if _, err := c.Zones.Create(udnssdk.NewAliasZone(s.Account, "example.info.", "missing.example.")); !udnssdk.IsNotFound(err) {
+		t.Errorf("Create alias of missing zone: %v, want ErrNotFound", err)
+	}
+
+	s.PutRRSet("example.com.", udnssdk.RRSet{OwnerName: "www", RRType: "A", RData: []string{"192.0.2.1"}})
+	z, _, err := c.Zones.Find("example.com.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if z.Properties.ResourceRecordCount != 1 || z.Properties.Status != "ACTIVE" {
+		t.Errorf("Find: %+v", z.Properties)
+	}
+
+	zones, err := c.Zones.Select(udnssdk.Query{Name: "example", ZoneType: udnssdk.SecondaryZoneType})
+	if err != nil || len(zones) != 1 || zones[0].Properties.Name != "example.net." {
+		t.Errorf("Select: %+v, %v", zones, err)
+	}
+	if zones[0].PrimaryNameServers == nil || zones[0].PrimaryNameServers.NameServerIPList.NameServerIP1.IP != "192.0.2.53" {
+		t.Errorf("PrimaryNameServers: %+v", zones[0].PrimaryNameServers)
+	}
+
+	if _, err := c.Zones.Delete("example.com."); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.Zones.Find("example.com."); !udnssdk.IsNotFound(err) {
+		t.Errorf("Find after Delete: %v, want ErrNotFound", err)
+	}
+	if _, err := c.RRSets.Select(udnssdk.RRSetKey{Zone: "example.com."}); !udnssdk.IsNotFound(err) {
+		t.Errorf("RRSets.Select after Delete: %v, want ErrNotFound", err)
+	}
+}
+
+func Test_Batch(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.PutRRSet("example.com.", udnssdk.RRSet{OwnerName: "dup", RRType: "A", RData: []string{"192.0.2.9"}})
+	c := newTestClient(t, s, udnssdk.WithTaskWaiter(udnssdk.TaskWaiter{PollInterval: time.Millisecond, Async: true}))
+
+	b := c.NewBatch()
+	b.Size = 2
+	ops := []*udnssdk.BatchOp{}
+	for i := 1; i <= 3; i++ {
+		k := udnssdk.RRSetKey{Zone: "example.com.", Type: "A", Name: fmt.Sprintf("host%d", i)}
+		ops = append(ops, b.CreateRRSet(k, udnssdk.RRSet{TTL: 300, RData: []string{fmt.Sprintf("192.0.2.%d", i)}}))
+	}
+	dup := b.CreateRRSet(udnssdk.RRSetKey{Zone: "example.com.", Type: "A", Name: "dup"}, udnssdk.RRSet{TTL: 300, RData: []string{"192.0.2.9"}})
+	nk := udnssdk.NotificationKey{Zone: "example.com.", Type: "A", Name: "host1", Email: "ops@example.com"}
+	ops = append(ops, b.CreateNotification(nk, udnssdk.NotificationDTO{PoolRecords: []udnssdk.NotificationPoolRecord{{PoolRecord: "192.0.2.1"}}}))
+
+	err := b.Submit()
+	if !udnssdk.IsAlreadyExists(err) || !udnssdk.IsAlreadyExists(dup.Err) {
+		t.Fatalf("Submit: %v", err)
+	}
+	for _, op := range ops {
+		if op.Err != nil {
+			t.Errorf("%s %s: %v", op.Method, op.Path, op.Err)
+		}
+	}
+
+	if rrsets := s.RRSets("example.com."); len(rrsets) != 4 {
+		t.Errorf("RRSets: %+v", rrsets)
+	}
+	if _, _, err := c.Notifications.Find(nk); err != nil {
+		t.Errorf("Notifications.Find: %v", err)
+	}
+	if tasks := s.Tasks(); len(tasks) != 3 {
+		t.Errorf("Tasks: %+v", tasks)
+	}
+
+	// submitting again only sends the failed operation, in a single batch
+	if _, err := c.RRSets.Delete(udnssdk.RRSetKey{Zone: "example.com.", Type: "A", Name: "dup"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Submit(); err != nil || dup.Err != nil {
+		t.Fatalf("Submit again: %v, %v", err, dup.Err)
+	}
+	if tasks := s.Tasks(); len(tasks) != 4 {
+		t.Errorf("Tasks after submitting again: %+v", tasks)
+	}
+	if rrsets := s.RRSets("example.com."); len(rrsets) != 4 {
+		t.Errorf("RRSets after submitting again: %+v", rrsets)
+	}
+}
+
+func Test_RRSets_Query(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.PutRRSet("example.com.", udnssdk.RRSet{OwnerName: "a", RRType: "CNAME", TTL: 300, RData: []string{"lb.example.net."}})
+	s.PutRRSet("example.com.", udnssdk.RRSet{OwnerName: "b", RRType: "CNAME", TTL: 60, RData: []string{"lb.example.net."}})
+	s.PutRRSet("example.com.", udnssdk.RRSet{OwnerName: "c", RRType: "CNAME", TTL: 300, RData: []string{"other.example.net."}})
+	s.PutRRSet("example.com.", udnssdk.RRSet{OwnerName: "lb", RRType: "TXT", TTL: 300, RData: []string{"lb.example.net."}})
+	s.PutRRSet("example.com.", udnssdk.RRSet{OwnerName: "pool", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"},
+		Profile: udnssdk.RawProfile{"@context": string(udnssdk.RDPoolSchema), "order": "FIXED"}})
+	c := newTestClient(t, s)
+	k := udnssdk.RRSetKey{Zone: "example.com."}
+
+	rrsets, err := c.RRSets.SelectQuery(k, udnssdk.Query{Type: "CNAME", Value: "lb.example.net.", Sort: "TTL"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, rr := range rrsets {
+		got = append(got, rr.OwnerName)
+	}
+	if want := "[b.example.com. a.example.com.]"; fmt.Sprint(got) != want {
+		t.Errorf("Select: %v, want: %s", got, want)
+	}
+
+	pools, err := c.RRSets.SelectQuery(k, udnssdk.Query{Kind: udnssdk.PoolsKind})
+	if err != nil || len(pools) != 1 || pools[0].OwnerName != "pool.example.com." {
+		t.Errorf("Select pools: %+v, %v", pools, err)
+	}
+}
+
+func Test_RRSets_Patch(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.PutRRSet("example.com.", udnssdk.RRSet{
+		OwnerName: "pool",
+		RRType:    "A",
+		TTL:       300,
+		RData:     []string{"192.0.2.1", "192.0.2.2"},
+		Profile: udnssdk.SBPoolProfile{
+			MaxActive: 1,
+			RDataInfo: []udnssdk.SBRDataInfo{
+				{State: udnssdk.SBStateNormal, RunProbes: true, Priority: 1, Threshold: 1},
+				{State: udnssdk.SBStateNormal, RunProbes: true, Priority: 2, Threshold: 1},
+			},
+		},
+	})
+	c := newTestClient(t, s)
+	k := udnssdk.RRSetKey{Zone: "example.com.", Type: "A", Name: "pool"}
+	pool := func() (udnssdk.RRSet, udnssdk.SBPoolProfile) {
+		rr := s.RRSets("example.com.")[0]
+		p, _ := rr.Profile.(udnssdk.SBPoolProfile)
+		return rr, p
+	}
+
+	if _, err := c.RRSets.AddRData(k, "192.0.2.3"); err != nil {
+		t.Fatalf("AddRData: %v", err)
+	}
+	rr, p := pool()
+	if !reflect.DeepEqual(rr.RData, []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}) || len(p.RDataInfo) != 3 || p.RDataInfo[2].Priority != 3 {
+		t.Errorf("after AddRData: %+v %+v", rr.RData, p.RDataInfo)
+	}
+
+	if _, err := c.RRSets.RemoveRData(k, "192.0.2.1"); err != nil {
+		t.Fatalf("RemoveRData: %v", err)
+	}
+	rr, p = pool()
+	if !reflect.DeepEqual(rr.RData, []string{"192.0.2.2", "192.0.2.3"}) || len(p.RDataInfo) != 2 || p.RDataInfo[0].Priority != 2 {
+		t.Errorf("after RemoveRData: %+v %+v", rr.RData, p.RDataInfo)
+	}
+
+	if _, err := c.RRSets.SetProfileField(k, "/rdataInfo/1/state", udnssdk.SBStateInactive); err != nil {
+		t.Fatalf("SetProfileField: %v", err)
+	}
+	if _, p = pool(); p.RDataInfo[1].State != udnssdk.SBStateInactive {
+		t.Errorf("after SetProfileField: %+v", p.RDataInfo)
+	}
+
+	ttl := 60
+	if _, err := c.RRSets.MergePatch(k, udnssdk.RRSetPatch{TTL: &ttl}); err != nil {
+		t.Fatalf("MergePatch: %v", err)
+	}
+	if rr, _ = pool(); rr.TTL != 60 || len(rr.RData) != 2 {
+		t.Errorf("after MergePatch: %+v", rr)
+	}
+
+	bad := udnssdk.JSONPatch{{Op: udnssdk.PatchAdd, Path: "/rdata/-", Value: "192.0.2.9"}}
+	if _, err := c.RRSets.Patch(k, bad); err == nil {
+		t.Errorf("Patch without rdataInfo: %v, want an error", err)
+	}
+	stale := udnssdk.JSONPatch{{Op: udnssdk.PatchTest, Path: "/rdata/0", Value: "192.0.2.1"}, {Op: udnssdk.PatchRemove, Path: "/rdata/0"}}
+	if _, err := c.RRSets.Patch(k, stale); err == nil {
+		t.Error("Patch with a failing test: no error")
+	}
+	if rr, _ = pool(); len(rr.RData) != 2 {
+		t.Errorf("failed patches changed the pool: %+v", rr)
+	}
+}
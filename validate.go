@@ -0,0 +1,244 @@
+package udnssdk
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MaxTTL is the largest TTL allowed by RFC 2181
+const MaxTTL = 1<<31 - 1
+
+// RRSetValidationError lists the problems Validate found with an RRSet.
+// It matches ErrValidation, like the API's own validation errors.
+type RRSetValidationError struct {
+	OwnerName string
+	RRType    string
+	Problems  []string
+}
+
+func (e *RRSetValidationError) Error() string {
+	return fmt.Sprintf("invalid RRSet %s %s: %s", e.OwnerName, e.RRType, strings.Join(e.Problems, "; "))
+}
+
+// Is reports whether target is ErrValidation
+func (e *RRSetValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// domainNameRData lists the rdata fields of each type that must be absolute domain names
+var domainNameRData = map[string]func(RData) []string{
+	"CNAME": func(r RData) []string { return []string{r.(CNAMERData).Target} },
+	"NS":    func(r RData) []string { return []string{r.(NSRData).Host} },
+	"PTR":   func(r RData) []string { return []string{r.(PTRRData).Target} },
+	"MX":    func(r RData) []string { return []string{r.(MXRData).Exchange} },
+	"SRV":   func(r RData) []string { return []string{r.(SRVRData).Target} },
+	"SOA":   func(r RData) []string { return []string{r.(SOARData).MName, r.(SOARData).RName} },
+}
+
+// Validate checks r before it is submitted: the owner name's syntax and length, if set,
+// the TTL bounds, the rdata syntax of the types ParseRData supports, absolute domain names in
+// rdata, a single record for CNAMEs and, for pool profiles, one rdataInfo per record.
+// Exclusivity of a CNAME with other RRSets of its owner is checked by ValidateRRSets.
+func (r RRSet) Validate() error {
+	e := &RRSetValidationError{OwnerName: r.OwnerName, RRType: r.RRType}
+	t := rrTypeName(r.RRType)
+
+	if r.OwnerName != "" {
+		if err := validateDomainName(r.OwnerName); err != nil {
+			e.Problems = append(e.Problems, fmt.Sprintf("owner name: %v", err))
+		}
+	}
+	if t == "" {
+		e.Problems = append(e.Problems, "rrtype is required")
+	}
+	if r.TTL < 0 || r.TTL > MaxTTL {
+		e.Problems = append(e.Problems, fmt.Sprintf("ttl %d is outside 0-%d", r.TTL, MaxTTL))
+	}
+
+	if len(r.RData) == 0 {
+		e.Problems = append(e.Problems, "at least one rdata is required")
+	}
+	if t == "CNAME" && len(r.RData) > 1 {
+		e.Problems = append(e.Problems, fmt.Sprintf("a CNAME holds one record, not %d", len(r.RData)))
+	}
+	seen := map[string]bool{}
+	for _, s := range r.RData {
+		if seen[s] {
+			e.Problems = append(e.Problems, fmt.Sprintf("duplicate rdata %q", s))
+		}
+		seen[s] = true
+		if t == "" {
+			continue
+		}
+
+		rd, err := ParseRData(t, s)
+		if errors.Is(err, errUnsupportedRRType) {
+			continue
+		}
+		if err != nil {
+			e.Problems = append(e.Problems, err.Error())
+			continue
+		}
+		if names, ok := domainNameRData[t]; ok {
+			for _, n := range names(rd) {
+				if !strings.HasSuffix(n, ".") {
+					e.Problems = append(e.Problems, fmt.Sprintf("rdata %q: %q is not an absolute name ending in a dot", s, n))
+				} else if err := validateDomainName(n); err != nil {
+					e.Problems = append(e.Problems, fmt.Sprintf("rdata %q: %v", s, err))
+				}
+			}
+		}
+	}
+
+	e.Problems = append(e.Problems, profileProblems(r.Profile, len(r.RData))...)
+
+	if len(e.Problems) != 0 {
+		return e
+	}
+	return nil
+}
+
+// profileProblems returns the inconsistencies of a pool profile with an RRSet of n records
+func profileProblems(p Profile, n int) []string {
+	if p == nil {
+		return nil
+	}
+	rp, err := ToRawProfile(p)
+	if err != nil {
+		return []string{fmt.Sprintf("profile: %v", err)}
+	}
+	c, ok := rp["@context"].(string)
+	if !ok || c == "" {
+		return []string{"profile has no @context"}
+	}
+	switch ProfileSchema(c) {
+	case DirPoolSchema, SBPoolSchema, TCPoolSchema:
+		v := reflect.ValueOf(rp["rdataInfo"])
+		if v.Kind() != reflect.Slice {
+			return []string{fmt.Sprintf("%s profile has no rdataInfo", c)}
+		}
+		if v.Len() != n {
+			return []string{fmt.Sprintf("profile has %d rdataInfo for %d rdata", v.Len(), n)}
+		}
+		if ProfileSchema(c) == DirPoolSchema {
+			dp, err := rp.DirPoolProfile()
+			if err != nil {
+				return []string{fmt.Sprintf("profile: %v", err)}
+			}
+			return dp.geoProblems()
+		}
+	case SFPoolSchema, SLBPoolSchema:
+		// typed from a RawProfile too, so that both are checked alike
+		tp, err := rp.Profile()
+		if err != nil {
+			return []string{fmt.Sprintf("profile: %v", err)}
+		}
+		if v, ok := tp.(interface{ problems(int) []string }); ok {
+			return v.problems(n)
+		}
+	}
+	return nil
+}
+
+// geoProblems returns the problems with the codes of the geo groups of the profile, and the codes held by more than one group.
+// Account-level groups, which the profile only names, are not checked.
+func (p DirPoolProfile) geoProblems() []string {
+	infos := append([]DPRDataInfo{}, p.RDataInfo...)
+	if p.NoResponse != (DPRDataInfo{}) {
+		infos = append(infos, p.NoResponse)
+	}
+	problems := []string{}
+	owner := map[string]string{}
+	for _, info := range infos {
+		g := info.GeoInfo
+		if g == nil || g.IsAccountLevel {
+			continue
+		}
+		for _, problem := range geoCodeProblems(g.Codes) {
+			problems = append(problems, fmt.Sprintf("geo group %s: %s", g.Name, problem))
+		}
+		for _, code := range g.Codes {
+			code = strings.ToUpper(strings.TrimSpace(code))
+			if other, ok := owner[code]; ok && other != g.Name {
+				problems = append(problems, fmt.Sprintf("code %q is in geo groups %s and %s", code, other, g.Name))
+			}
+			owner[code] = g.Name
+		}
+	}
+	return problems
+}
+
+// ValidateRRSets validates each RRSet and checks that no owner has a CNAME alongside other RRSets.
+// The problems of all RRSets are joined in the returned error.
+func ValidateRRSets(rrsets []RRSet) error {
+	errs := []error{}
+	types := map[string][]string{}
+	for _, rr := range rrsets {
+		if err := rr.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+		owner := strings.ToLower(fqdn(rr.OwnerName))
+		types[owner] = append(types[owner], rrTypeName(rr.RRType))
+	}
+	for _, rr := range rrsets {
+		owner := strings.ToLower(fqdn(rr.OwnerName))
+		if rrTypeName(rr.RRType) == "CNAME" && len(types[owner]) > 1 {
+			errs = append(errs, &RRSetValidationError{
+				OwnerName: rr.OwnerName,
+				RRType:    rr.RRType,
+				Problems:  []string{fmt.Sprintf("a CNAME cannot coexist with the %s RRSets of its owner", strings.Join(types[owner], ", "))},
+			})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateDomainName checks the syntax of a relative or absolute domain name:
+// at most 253 characters, labels of 1-63 letters, digits, hyphens and underscores
+// not starting or ending with a hyphen, and "*" only as the first label
+func validateDomainName(name string) error {
+	n := strings.TrimSuffix(name, ".")
+	if n == "" {
+		if name == "." {
+			return nil
+		}
+		return fmt.Errorf("empty name")
+	}
+	if len(n) > 253 {
+		return fmt.Errorf("%q is longer than 253 characters", name)
+	}
+	for i, label := range strings.Split(n, ".") {
+		switch {
+		case label == "":
+			return fmt.Errorf("%q has an empty label", name)
+		case len(label) > 63:
+			return fmt.Errorf("%q has a label longer than 63 characters", name)
+		case label == "*":
+			if i != 0 {
+				return fmt.Errorf("%q has a wildcard that is not the first label", name)
+			}
+			continue
+		case label[0] == '-' || label[len(label)-1] == '-':
+			return fmt.Errorf("%q has a label starting or ending with a hyphen", name)
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+				return fmt.Errorf("%q contains %q", name, c)
+			}
+		}
+	}
+	return nil
+}
+
+// validate checks rrset before it is written to k, taking the owner and type from k if rrset lacks them
+func (k RRSetKey) validate(rrset RRSet) error {
+	if rrset.OwnerName == "" {
+		rrset.OwnerName = k.Name
+	}
+	if rrset.RRType == "" {
+		rrset.RRType = k.Type
+	}
+	return rrset.Validate()
+}
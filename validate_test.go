@@ -0,0 +1,154 @@
+package udnssdk
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_RRSet_Validate(t *testing.T) {
+	valid := []RRSet{
+		{OwnerName: "www.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"192.0.2.1", "192.0.2.2"}},
+		{OwnerName: "*.example.com.", RRType: "CNAME", TTL: 300, RData: []string{"www.example.com."}},
+		{OwnerName: "_sip._tcp", RRType: "SRV", TTL: 0, RData: []string{"10 60 5060 sip.example.com."}},
+		{OwnerName: "example.com.", RRType: "HINFO", TTL: 300, RData: []string{"PC Linux"}},
+		{RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}, Profile: RawProfile{
+			"@context":  string(SBPoolSchema),
+			"rdataInfo": []interface{}{map[string]interface{}{"priority": 1}},
+		}},
+		{RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}, Profile: SFPoolProfile{
+			RegionFailureSensitivity: SensitivityHigh,
+			Monitor:                  Monitor{Method: "GET", URL: "http://192.0.2.1/"},
+			BackupRecord:             &SFBackupRecord{RData: "192.0.2.9"},
+		}},
+		{RRType: "A", TTL: 300, RData: []string{"192.0.2.1", "192.0.2.2"}, Profile: SLBPoolProfile{
+			RegionFailureSensitivity: SensitivityLow, ResponseMethod: ResponseRandom, ServingPreference: ServeAutoSelect,
+			Monitor:       Monitor{Method: "POST", URL: "https://example.com/check", TransmittedData: "x"},
+			RDataInfo:     []SLBRDataInfo{{ProbingEnabled: true}, {ProbingEnabled: true}},
+			AllFailRecord: &AllFailRecord{RData: "192.0.2.9"},
+		}},
+	}
+	for _, rr := range valid {
+		if err := rr.Validate(); err != nil {
+			t.Errorf("Validate(%+v): %v", rr, err)
+		}
+	}
+
+	cases := []struct {
+		rr   RRSet
+		want string
+	}{
+		{RRSet{OwnerName: "www.example.com.", TTL: 300, RData: []string{"192.0.2.1"}}, "rrtype is required"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: -1, RData: []string{"192.0.2.1"}}, "ttl -1 is outside"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: MaxTTL + 1, RData: []string{"192.0.2.1"}}, "ttl 2147483648 is outside"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300}, "at least one rdata"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1", "192.0.2.1"}}, "duplicate rdata"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"2001:db8::1"}}, "not an IPv4 address"},
+		{RRSet{OwnerName: "www..example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}}, "empty label"},
+		{RRSet{OwnerName: "a.*.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}}, "wildcard"},
+		{RRSet{OwnerName: "-a.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}}, "hyphen"},
+		{RRSet{OwnerName: "a b.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}}, "contains ' '"},
+		{RRSet{OwnerName: strings.Repeat("a", 64) + ".example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}}, "longer than 63"},
+		{RRSet{OwnerName: strings.Repeat("abcdefghi.", 26), RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}}, "longer than 253"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "CNAME", TTL: 300, RData: []string{"a.example.com.", "b.example.com."}}, "a CNAME holds one record"},
+		{RRSet{OwnerName: "example.com.", RRType: "MX", TTL: 300, RData: []string{"10 mail"}}, "not an absolute name"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}, Profile: RawProfile{"order": "FIXED"}}, "no @context"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1", "192.0.2.2"}, Profile: RawProfile{
+			"@context":  string(TCPoolSchema),
+			"rdataInfo": []interface{}{map[string]interface{}{"priority": 1}},
+		}}, "1 rdataInfo for 2 rdata"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}, Profile: RawProfile{
+			"@context": string(DirPoolSchema),
+		}}, "has no rdataInfo"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1", "192.0.2.2"}, Profile: SFPoolProfile{
+			RegionFailureSensitivity: SensitivityHigh,
+			Monitor:                  Monitor{Method: "GET", URL: "http://192.0.2.1/"},
+			BackupRecord:             &SFBackupRecord{RData: "192.0.2.9"},
+		}}, "one live record, not 2"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}, Profile: RawProfile{
+			"@context":                 string(SFPoolSchema),
+			"regionFailureSensitivity": "MEDIUM",
+			"monitor":                  map[string]interface{}{"method": "GET", "url": "http://192.0.2.1/"},
+			"backupRecord":             map[string]interface{}{"rdata": "192.0.2.9"},
+		}}, `regionFailureSensitivity "MEDIUM"`},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}, Profile: SFPoolProfile{
+			RegionFailureSensitivity: SensitivityHigh,
+			Monitor:                  Monitor{Method: "GET", URL: "192.0.2.1/health"},
+			BackupRecord:             &SFBackupRecord{RData: "192.0.2.9"},
+		}}, "not an absolute http or https URL"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}, Profile: SFPoolProfile{
+			RegionFailureSensitivity: SensitivityHigh,
+			Monitor:                  Monitor{Method: "GET", URL: "http://192.0.2.1/"},
+		}}, "needs a backupRecord"},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}, Profile: SLBPoolProfile{
+			RegionFailureSensitivity: SensitivityLow, ResponseMethod: "FIXED", ServingPreference: ServeAutoSelect,
+			Monitor:       Monitor{Method: "GET", URL: "http://example.com/"},
+			RDataInfo:     []SLBRDataInfo{{}},
+			AllFailRecord: &AllFailRecord{RData: "192.0.2.9"},
+		}}, `responseMethod "FIXED"`},
+		{RRSet{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}, Profile: SLBPoolProfile{
+			RegionFailureSensitivity: SensitivityLow, ResponseMethod: ResponseRandom, ServingPreference: ServeAutoSelect,
+			Monitor:       Monitor{Method: "GET", URL: "http://example.com/", TransmittedData: "x"},
+			RDataInfo:     []SLBRDataInfo{{}, {}},
+			AllFailRecord: &AllFailRecord{RData: "192.0.2.9"},
+		}}, "1 rdata"},
+	}
+	for _, c := range cases {
+		err := c.rr.Validate()
+		if err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("Validate(%+v): %v, want error containing %q", c.rr, err, c.want)
+			continue
+		}
+		if !IsValidation(err) {
+			t.Errorf("IsValidation(%v): false", err)
+		}
+	}
+}
+
+func Test_ValidateRRSets(t *testing.T) {
+	rrsets := []RRSet{
+		{OwnerName: "www.example.com.", RRType: "CNAME", TTL: 300, RData: []string{"web.example.com."}},
+		{OwnerName: "WWW.example.com", RRType: "TXT", TTL: 300, RData: []string{"hello"}},
+		{OwnerName: "web.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1"}},
+	}
+	err := ValidateRRSets(rrsets)
+	if err == nil || !strings.Contains(err.Error(), "cannot coexist with the CNAME, TXT RRSets") {
+		t.Fatalf("ValidateRRSets: %v", err)
+	}
+	var ve *RRSetValidationError
+	if !errors.As(err, &ve) || ve.OwnerName != "www.example.com." {
+		t.Errorf("ValidateRRSets: %#v", err)
+	}
+
+	if err := ValidateRRSets(rrsets[2:]); err != nil {
+		t.Errorf("ValidateRRSets: %v", err)
+	}
+}
+
+func Test_RRSets_Create_Validation(t *testing.T) {
+	hits := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Write([]byte(`{"message":"Successful"}`))
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+	testClient.ValidateRRSets = true
+
+	k := RRSetKey{Zone: "example.com.", Type: "A", Name: "www.example.com."}
+	if _, err := testClient.RRSets.Create(k, RRSet{TTL: 300, RData: []string{"192.0.2.1"}}); err != nil {
+		t.Errorf("Create: %v", err)
+	}
+	if _, err := testClient.RRSets.Create(k, RRSet{TTL: 300, RData: []string{"bogus"}}); !IsValidation(err) {
+		t.Errorf("Create: %v", err)
+	}
+	if _, err := testClient.RRSets.Update(k, RRSet{TTL: -5, RData: []string{"192.0.2.1"}}); !IsValidation(err) {
+		t.Errorf("Update: %v", err)
+	}
+	if hits != 1 {
+		t.Errorf("requests: %d, want: %d", hits, 1)
+	}
+}
@@ -0,0 +1,255 @@
+package udnssdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ZonesService provides access to zone resources
+type ZonesService struct {
+	client *Client
+}
+
+// ZoneType is the kind of a zone
+type ZoneType string
+
+// Here lie all ZoneTypes
+const (
+	PrimaryZoneType   ZoneType = "PRIMARY"
+	SecondaryZoneType ZoneType = "SECONDARY"
+	AliasZoneType     ZoneType = "ALIAS"
+)
+
+// Zone wraps a zone resource. Only one of the create infos is set, matching Properties.Type.
+type Zone struct {
+	Properties          ZoneProperties           `json:"properties"`
+	PrimaryCreateInfo   *PrimaryZoneCreateInfo   `json:"primaryCreateInfo,omitempty"`
+	SecondaryCreateInfo *SecondaryZoneCreateInfo `json:"secondaryCreateInfo,omitempty"`
+	AliasCreateInfo     *AliasZoneCreateInfo     `json:"aliasCreateInfo,omitempty"`
+	RegistrarInfo       *RegistrarInfo           `json:"registrarInfo,omitempty"`
+	RestrictIPList      []RestrictIP             `json:"restrictIpList,omitempty"`
+	NotifyAddresses     []NotifyAddress          `json:"notifyAddresses,omitempty"`
+	OriginalZone        string                   `json:"originalZone,omitempty"`
+	PrimaryNameServers  *PrimaryNameServers      `json:"primaryNameServers,omitempty"`
+}
+
+// ZoneProperties wraps the properties of a zone. The status, record count and
+// modification fields are read-only and ignored on Create and Update.
+type ZoneProperties struct {
+	Name                 string   `json:"name"`
+	AccountName          string   `json:"accountName"`
+	Type                 ZoneType `json:"type"`
+	Status               string   `json:"status,omitempty"`
+	DNSSECStatus         string   `json:"dnssecStatus,omitempty"`
+	Owner                string   `json:"owner,omitempty"`
+	ResourceRecordCount  int      `json:"resourceRecordCount,omitempty"`
+	LastModifiedDateTime string   `json:"lastModifiedDateTime,omitempty"`
+}
+
+// PrimaryZoneCreateInfo describes how a primary zone is created
+type PrimaryZoneCreateInfo struct {
+	// CreateType is one of NEW, COPY, TRANSFER or UPLOAD
+	CreateType       string        `json:"createType"`
+	ForceImport      bool          `json:"forceImport,omitempty"`
+	OriginalZoneName string        `json:"originalZoneName,omitempty"`
+	NameServer       *NameServerIP `json:"nameServer,omitempty"`
+	RestrictIPList   []RestrictIP  `json:"restrictIpList,omitempty"`
+}
+
+// SecondaryZoneCreateInfo describes the primaries a secondary zone transfers from
+type SecondaryZoneCreateInfo struct {
+	PrimaryNameServers       PrimaryNameServers `json:"primaryNameServers"`
+	NotificationEmailAddress string             `json:"notificationEmailAddress,omitempty"`
+}
+
+// AliasZoneCreateInfo names the zone an alias zone mirrors
+type AliasZoneCreateInfo struct {
+	OriginalZoneName string `json:"originalZoneName"`
+}
+
+// PrimaryNameServers wraps the up to three primaries of a secondary zone
+type PrimaryNameServers struct {
+	NameServerIPList NameServerIPList `json:"nameServerIpList"`
+}
+
+// NameServerIPList wraps the primaries of a secondary zone
+type NameServerIPList struct {
+	NameServerIP1 *NameServerIP `json:"nameServerIp1,omitempty"`
+	NameServerIP2 *NameServerIP `json:"nameServerIp2,omitempty"`
+	NameServerIP3 *NameServerIP `json:"nameServerIp3,omitempty"`
+}
+
+// NameServerIP wraps a name server address with its optional TSIG key
+type NameServerIP struct {
+	IP            string `json:"ip"`
+	TSIGKey       string `json:"tsigKey,omitempty"`
+	TSIGKeyValue  string `json:"tsigKeyValue,omitempty"`
+	TSIGAlgorithm string `json:"tsigAlgorithm,omitempty"`
+}
+
+// RegistrarInfo wraps the registrar details of a zone
+type RegistrarInfo struct {
+	Registrar       string               `json:"registrar,omitempty"`
+	WhoisExpiration string               `json:"whoisExpiration,omitempty"`
+	NameServers     RegistrarNameServers `json:"nameServers"`
+}
+
+// RegistrarNameServers groups the delegated name servers of a zone by whether they match UltraDNS's
+type RegistrarNameServers struct {
+	OK        []string `json:"ok,omitempty"`
+	Unknown   []string `json:"unknown,omitempty"`
+	Missing   []string `json:"missing,omitempty"`
+	Incorrect []string `json:"incorrect,omitempty"`
+}
+
+// RestrictIP wraps an address range allowed to transfer a zone
+type RestrictIP struct {
+	StartIP  string `json:"startIP,omitempty"`
+	EndIP    string `json:"endIP,omitempty"`
+	CIDR     string `json:"cidr,omitempty"`
+	SingleIP string `json:"singleIP,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// NotifyAddress wraps an address notified of zone changes
+type NotifyAddress struct {
+	NotifyAddress string `json:"notifyAddress"`
+	Description   string `json:"description,omitempty"`
+}
+
+// ZoneListDTO wraps a list of zone resources
+type ZoneListDTO struct {
+	Zones      []Zone     `json:"zones"`
+	Queryinfo  QueryInfo  `json:"queryInfo"`
+	Resultinfo ResultInfo `json:"resultInfo"`
+}
+
+// NewPrimaryZone returns a Zone which creates an empty primary zone
+func NewPrimaryZone(account, name string) Zone {
+	return Zone{
+		Properties:        ZoneProperties{Name: name, AccountName: account, Type: PrimaryZoneType},
+		PrimaryCreateInfo: &PrimaryZoneCreateInfo{CreateType: "NEW", ForceImport: true},
+	}
+}
+
+// NewSecondaryZone returns a Zone which creates a secondary zone transferred from up to three primaries
+func NewSecondaryZone(account, name string, primaries ...NameServerIP) Zone {
+	var l NameServerIPList
+	for i := range primaries {
+		p := &primaries[i]
+		switch i {
+		case 0:
+			l.NameServerIP1 = p
+		case 1:
+			l.NameServerIP2 = p
+		case 2:
+			l.NameServerIP3 = p
+		}
+	}
+	return Zone{
+		Properties:          ZoneProperties{Name: name, AccountName: account, Type: SecondaryZoneType},
+		SecondaryCreateInfo: &SecondaryZoneCreateInfo{PrimaryNameServers: PrimaryNameServers{NameServerIPList: l}},
+	}
+}
+
+// NewAliasZone returns a Zone which creates an alias of original
+func NewAliasZone(account, name, original string) Zone {
+	return Zone{
+		Properties:      ZoneProperties{Name: name, AccountName: account, Type: AliasZoneType},
+		AliasCreateInfo: &AliasZoneCreateInfo{OriginalZoneName: original},
+	}
+}
+
+// ZoneKey represents the name of a Zone
+type ZoneKey string
+
+// URI generates the URI for a Zone
+func (k ZoneKey) URI() string {
+	if k == "" {
+		return ZonesURI()
+	}
+	return fmt.Sprintf("zones/%s", k)
+}
+
+// ZonesURI generates the URI for the Zones collection
+func ZonesURI() string {
+	return "zones"
+}
+
+// Select requests all zones matching q, paginating through all available results.
+// Zones are matched by the Name, ZoneType, ZoneStatus and AccountName of q.
+func (s *ZonesService) Select(q Query) ([]Zone, error) {
+	return s.SelectContext(context.Background(), q)
+}
+
+// SelectContext is Select with a context.Context
+func (s *ZonesService) SelectContext(ctx context.Context, q Query) ([]Zone, error) {
+	return s.Pager(q).Collect(ctx)
+}
+
+// SelectWithOffset requests a page of zones matching q, starting at offset
+func (s *ZonesService) SelectWithOffset(q Query, offset int) ([]Zone, ResultInfo, *http.Response, error) {
+	return s.SelectWithOffsetContext(context.Background(), q, offset)
+}
+
+// SelectWithOffsetContext is SelectWithOffset with a context.Context
+func (s *ZonesService) SelectWithOffsetContext(ctx context.Context, q Query, offset int) ([]Zone, ResultInfo, *http.Response, error) {
+	var zld ZoneListDTO
+	res, err := s.client.get(ctx, q.URI(ZonesURI(), offset), &zld)
+
+	zones := []Zone{}
+	zones = append(zones, zld.Zones...)
+	return zones, zld.Resultinfo, res, err
+}
+
+// Pager returns a Pager over the zones matching q
+func (s *ZonesService) Pager(q Query) *Pager[Zone] {
+	return newQueryPager(s.client, q, func(ctx context.Context, q Query, offset int) ([]Zone, ResultInfo, *http.Response, error) {
+		return s.SelectWithOffsetContext(ctx, q, offset)
+	})
+}
+
+// Find requests a zone by ZoneKey
+func (s *ZonesService) Find(k ZoneKey) (Zone, *http.Response, error) {
+	return s.FindContext(context.Background(), k)
+}
+
+// FindContext is Find with a context.Context
+func (s *ZonesService) FindContext(ctx context.Context, k ZoneKey) (Zone, *http.Response, error) {
+	var z Zone
+	res, err := s.client.get(ctx, k.URI(), &z)
+	return z, res, err
+}
+
+// Create creates the zone z, as described by its Properties and create info
+func (s *ZonesService) Create(z Zone) (*http.Response, error) {
+	return s.CreateContext(context.Background(), z)
+}
+
+// CreateContext is Create with a context.Context
+func (s *ZonesService) CreateContext(ctx context.Context, z Zone) (*http.Response, error) {
+	var ignored interface{}
+	return s.client.post(ctx, ZonesURI(), z, &ignored)
+}
+
+// Update replaces the zone identified by k with z
+func (s *ZonesService) Update(k ZoneKey, z Zone) (*http.Response, error) {
+	return s.UpdateContext(context.Background(), k, z)
+}
+
+// UpdateContext is Update with a context.Context
+func (s *ZonesService) UpdateContext(ctx context.Context, k ZoneKey, z Zone) (*http.Response, error) {
+	var ignored interface{}
+	return s.client.put(ctx, k.URI(), z, &ignored)
+}
+
+// Delete deletes a zone and all of its records
+func (s *ZonesService) Delete(k ZoneKey) (*http.Response, error) {
+	return s.DeleteContext(context.Background(), k)
+}
+
+// DeleteContext is Delete with a context.Context
+func (s *ZonesService) DeleteContext(ctx context.Context, k ZoneKey) (*http.Response, error) {
+	return s.client.delete(ctx, k.URI(), nil)
+}
@@ -0,0 +1,168 @@
+package udnssdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func Test_Zones_Select_Live(t *testing.T) {
+	if !enableIntegrationTests {
+		t.SkipNow()
+	}
+
+	testClient, err := NewClient(testUsername, testPassword, testBaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zones, err := testClient.Zones.Select(Query{Name: testDomain})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Zones: %+v \n", zones)
+}
+
+func Test_Query_ZonesURI(t *testing.T) {
+	q := Query{Name: "example", ZoneType: PrimaryZoneType, AccountName: "terraform", Sort: "NAME", Reverse: true, Limit: 10}
+	want := "zones?limit=10&offset=20&q=name%3Aexample+zone_type%3APRIMARY+account_name%3Aterraform&reverse=true&sort=NAME"
+
+	if uri := q.URI(ZonesURI(), 20); uri != want {
+		t.Errorf("URI: %q, want: %q", uri, want)
+	}
+	if uri, want := (Query{}).URI(ZonesURI(), 0), "zones?offset=0"; uri != want {
+		t.Errorf("URI: %q, want: %q", uri, want)
+	}
+}
+
+func Test_ZoneKey_URI(t *testing.T) {
+	if uri, want := ZoneKey("example.com.").URI(), "zones/example.com."; uri != want {
+		t.Errorf("URI: %q, want: %q", uri, want)
+	}
+}
+
+func Test_Zones_Select(t *testing.T) {
+	all := []Zone{}
+	for i := 0; i < 5; i++ {
+		all = append(all, Zone{Properties: ZoneProperties{
+			Name:                fmt.Sprintf("zone%d.example.", i),
+			AccountName:         "terraform",
+			Type:                PrimaryZoneType,
+			Status:              "ACTIVE",
+			ResourceRecordCount: i,
+		}})
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("q"); q != "zone_type:PRIMARY" {
+			t.Errorf("q: %q, want: %q", q, "zone_type:PRIMARY")
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + 2
+		if end > len(all) {
+			end = len(all)
+		}
+		resp := ZoneListDTO{
+			Zones:      all[offset:end],
+			Resultinfo: ResultInfo{TotalCount: len(all), Offset: offset, ReturnedCount: end - offset},
+		}
+		mess, _ := json.Marshal(resp)
+		fmt.Fprintln(w, string(mess))
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+
+	zones, err := testClient.Zones.Select(Query{ZoneType: PrimaryZoneType})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(zones, all) {
+		t.Errorf("zones: %+v, want: %+v", zones, all)
+	}
+}
+
+func Test_Zones_Find(t *testing.T) {
+	body := `{"properties":{"name":"example.com.","accountName":"terraform","type":"PRIMARY","dnssecStatus":"UNSIGNED","status":"ACTIVE","owner":"terraform","resourceRecordCount":7,"lastModifiedDateTime":"2024-01-02T03:04Z"},"registrarInfo":{"nameServers":{"missing":["pdns1.ultradns.net."]}}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/zones/example.com." {
+			t.Errorf("path: %q", r.URL.Path)
+		}
+		fmt.Fprintln(w, body)
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+
+	z, _, err := testClient.Zones.Find(ZoneKey("example.com."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ZoneProperties{
+		Name:                 "example.com.",
+		AccountName:          "terraform",
+		Type:                 PrimaryZoneType,
+		Status:               "ACTIVE",
+		DNSSECStatus:         "UNSIGNED",
+		Owner:                "terraform",
+		ResourceRecordCount:  7,
+		LastModifiedDateTime: "2024-01-02T03:04Z",
+	}
+	if z.Properties != want {
+		t.Errorf("Properties: %+v, want: %+v", z.Properties, want)
+	}
+	if z.RegistrarInfo == nil || len(z.RegistrarInfo.NameServers.Missing) != 1 {
+		t.Errorf("RegistrarInfo: %+v", z.RegistrarInfo)
+	}
+}
+
+func Test_Zones_Create(t *testing.T) {
+	cases := []struct {
+		zone Zone
+		want string
+	}{
+		{
+			NewPrimaryZone("terraform", "example.com."),
+			`{"properties":{"name":"example.com.","accountName":"terraform","type":"PRIMARY"},"primaryCreateInfo":{"createType":"NEW","forceImport":true}}`,
+		},
+		{
+			NewSecondaryZone("terraform", "example.net.", NameServerIP{IP: "192.0.2.53"}),
+			`{"properties":{"name":"example.net.","accountName":"terraform","type":"SECONDARY"},"secondaryCreateInfo":{"primaryNameServers":{"nameServerIpList":{"nameServerIp1":{"ip":"192.0.2.53"}}}}}`,
+		},
+		{
+			NewAliasZone("terraform", "example.org.", "example.com."),
+			`{"properties":{"name":"example.org.","accountName":"terraform","type":"ALIAS"},"aliasCreateInfo":{"originalZoneName":"example.com."}}`,
+		},
+	}
+
+	for _, c := range cases {
+		var got string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "POST" || r.URL.Path != "/v1/zones" {
+				t.Errorf("request: %s %s", r.Method, r.URL.Path)
+			}
+			var v interface{}
+			json.NewDecoder(r.Body).Decode(&v)
+			b, _ := json.Marshal(v)
+			got = string(b)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintln(w, `{"message":"Successful"}`)
+		}))
+
+		testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+		if _, err := testClient.Zones.Create(c.zone); err != nil {
+			t.Error(err)
+		}
+		var want interface{}
+		json.Unmarshal([]byte(c.want), &want)
+		b, _ := json.Marshal(want)
+		if got != string(b) {
+			t.Errorf("body: %s, want: %s", got, b)
+		}
+		ts.Close()
+	}
+}
@@ -0,0 +1,514 @@
+package udnssdk
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// DefaultZoneFileTTL is the $TTL of a zone file with no SOA record and no RRSets
+const DefaultZoneFileTTL = 86400
+
+// Export writes the RRSets of zone to w as an RFC 1035 master file
+func (s *RRSetsService) Export(w io.Writer, zone string) error {
+	return s.ExportContext(context.Background(), w, zone)
+}
+
+// ExportContext is Export with a context.Context
+func (s *RRSetsService) ExportContext(ctx context.Context, w io.Writer, zone string) error {
+	rrsets, err := s.SelectContext(ctx, RRSetKey{Zone: zone})
+	if err != nil {
+		return err
+	}
+	return WriteZoneFile(w, zone, rrsets)
+}
+
+// WriteZoneFile writes rrsets to w as an RFC 1035 master file for the zone origin.
+// Owner names within the zone are written relative to $ORIGIN, the apex as "@".
+// Every record carries an explicit TTL; $TTL is set to the SOA's TTL, or the most common one.
+// RRSets with a pool profile are preceded by comments naming its @context and description.
+func WriteZoneFile(w io.Writer, origin string, rrsets []RRSet) error {
+	origin = fqdn(strings.ToLower(origin))
+	sorted := sortZoneFile(origin, rrsets)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "; zone %s exported by udnssdk\n", origin)
+	fmt.Fprintf(bw, "$ORIGIN %s\n", origin)
+	fmt.Fprintf(bw, "$TTL %d\n", zoneFileTTL(sorted))
+
+	tw := tabwriter.NewWriter(bw, 0, 8, 1, ' ', 0)
+	for _, rr := range sorted {
+		owner := relativeName(rr.OwnerName, origin)
+		t := rrTypeName(rr.RRType)
+		if rr.Profile != nil {
+			fmt.Fprintln(tw)
+			if c := rr.Profile.Schema(); c != "" {
+				fmt.Fprintf(tw, "; @context: %s\n", c)
+			}
+			rp, _ := ToRawProfile(rr.Profile)
+			if d, ok := rp["description"].(string); ok && d != "" {
+				fmt.Fprintf(tw, "; description: %s\n", strings.Join(strings.Fields(d), " "))
+			}
+		}
+		for _, rdata := range rr.RData {
+			fmt.Fprintf(tw, "%s\t%d\tIN\t%s\t%s\n", owner, rr.TTL, t, formatRData(t, rdata))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// sortZoneFile returns rrsets ordered for a zone file: the apex first, starting with its SOA and NS,
+// then the other owners in name order
+func sortZoneFile(origin string, rrsets []RRSet) []RRSet {
+	sorted := append([]RRSet{}, rrsets...)
+	rank := func(rr RRSet) int {
+		switch rrTypeName(rr.RRType) {
+		case "SOA":
+			return 0
+		case "NS":
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := relativeName(sorted[i].OwnerName, origin), relativeName(sorted[j].OwnerName, origin)
+		if (a == "@") != (b == "@") {
+			return a == "@"
+		}
+		if a != b {
+			return a < b
+		}
+		if ra, rb := rank(sorted[i]), rank(sorted[j]); ra != rb {
+			return ra < rb
+		}
+		return rrTypeName(sorted[i].RRType) < rrTypeName(sorted[j].RRType)
+	})
+	return sorted
+}
+
+// zoneFileTTL picks the $TTL of a zone file: the SOA's TTL, or the most common TTL of rrsets
+func zoneFileTTL(rrsets []RRSet) int {
+	counts := map[int]int{}
+	best := DefaultZoneFileTTL
+	for _, rr := range rrsets {
+		if rrTypeName(rr.RRType) == "SOA" {
+			return rr.TTL
+		}
+		counts[rr.TTL]++
+		if counts[rr.TTL] > counts[best] || (counts[rr.TTL] == counts[best] && rr.TTL < best) {
+			best = rr.TTL
+		}
+	}
+	return best
+}
+
+// rrTypeName returns the mnemonic of an RRType as returned by the API, e.g. "A" for "A (1)"
+func rrTypeName(t string) string {
+	if i := strings.IndexByte(t, ' '); i >= 0 {
+		t = t[:i]
+	}
+	return strings.ToUpper(t)
+}
+
+// fqdn returns name with a trailing dot
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// relativeName returns owner relative to origin: "@" for the apex, the leading labels for names
+// within the zone, and the absolute name otherwise. Names without a trailing dot are already relative.
+func relativeName(owner, origin string) string {
+	if owner == "" || owner == "@" {
+		return "@"
+	}
+	if !strings.HasSuffix(owner, ".") {
+		return owner
+	}
+	lower := strings.ToLower(owner)
+	if lower == origin {
+		return "@"
+	}
+	if strings.HasSuffix(lower, "."+origin) {
+		return owner[:len(owner)-len(origin)-1]
+	}
+	return owner
+}
+
+// formatRData returns rdata in master file presentation format.
+// TXT and SPF rdata is quoted and split into character-strings of at most 255 bytes,
+// unless it is already quoted.
+func formatRData(t, rdata string) string {
+	if t != "TXT" && t != "SPF" {
+		return rdata
+	}
+	if strings.HasPrefix(rdata, `"`) {
+		return rdata
+	}
+	if rdata == "" {
+		return `""`
+	}
+	strs := []string{}
+	for len(rdata) > 0 {
+		n := len(rdata)
+		if n > 255 {
+			n = 255
+		}
+		strs = append(strs, quoteCharacterString(rdata[:n]))
+		rdata = rdata[n:]
+	}
+	return strings.Join(strs, " ")
+}
+
+// quoteCharacterString quotes s as an RFC 1035 character-string, escaping quotes, backslashes
+// and non-printable bytes
+func quoteCharacterString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c > 0x7e:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// ParseZoneFile parses an RFC 1035 master file into RRSets grouped by owner and type,
+// in order of first appearance. Owner names, and domain names in the rdata of NS, CNAME,
+// PTR, MX, SRV and SOA records, are made absolute using origin and $ORIGIN directives.
+// TXT and SPF rdata of one character-string is unquoted, and that of several is kept as quoted,
+// space-separated strings, the inverse of WriteZoneFile.
+// Records without a TTL take the $TTL, or the previous record's TTL.
+// Only the IN class is supported, and $INCLUDE is rejected.
+func ParseZoneFile(r io.Reader, origin string) ([]RRSet, error) {
+	lines, err := zoneFileLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	origin = fqdn(strings.ToLower(origin))
+	rrsets := []RRSet{}
+	index := map[string]int{}
+	defaultTTL, lastTTL := -1, -1
+	owner := ""
+
+	for _, l := range lines {
+		toks := l.tokens
+		if !l.blankOwner && strings.HasPrefix(toks[0].text, "$") {
+			switch strings.ToUpper(toks[0].text) {
+			case "$ORIGIN":
+				if len(toks) != 2 {
+					return nil, l.errorf("$ORIGIN takes one domain name")
+				}
+				origin = absoluteName(toks[1].text, origin)
+			case "$TTL":
+				if len(toks) != 2 {
+					return nil, l.errorf("$TTL takes one TTL")
+				}
+				ttl, ok := parseTTL(toks[1].text)
+				if !ok {
+					return nil, l.errorf("invalid $TTL %q", toks[1].text)
+				}
+				defaultTTL = ttl
+			default:
+				return nil, l.errorf("unsupported directive %s", toks[0].text)
+			}
+			continue
+		}
+
+		if !l.blankOwner {
+			owner = absoluteName(toks[0].text, origin)
+			toks = toks[1:]
+		} else if owner == "" {
+			return nil, l.errorf("record without an owner name")
+		}
+
+		ttl := -1
+		for len(toks) > 0 {
+			if t, ok := parseTTL(toks[0].text); ok && ttl < 0 {
+				ttl = t
+			} else if strings.EqualFold(toks[0].text, "IN") {
+				// the only class supported
+			} else if c := strings.ToUpper(toks[0].text); c == "CH" || c == "HS" || c == "CS" {
+				return nil, l.errorf("unsupported class %s", c)
+			} else {
+				break
+			}
+			toks = toks[1:]
+		}
+		if len(toks) == 0 {
+			return nil, l.errorf("missing record type")
+		}
+		switch {
+		case ttl >= 0:
+		case defaultTTL >= 0:
+			ttl = defaultTTL
+		case lastTTL >= 0:
+			ttl = lastTTL
+		default:
+			return nil, l.errorf("no TTL and no $TTL")
+		}
+		lastTTL = ttl
+
+		typ := strings.ToUpper(toks[0].text)
+		if len(toks) == 1 {
+			return nil, l.errorf("%s record without rdata", typ)
+		}
+		rdata := parseRData(typ, toks[1:], origin)
+
+		key := strings.ToLower(owner) + " " + typ
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(rrsets)
+			rrsets = append(rrsets, RRSet{OwnerName: owner, RRType: typ, TTL: ttl})
+			i = len(rrsets) - 1
+		}
+		if !containsString(rrsets[i].RData, rdata) {
+			rrsets[i].RData = append(rrsets[i].RData, rdata)
+		}
+	}
+	return rrsets, nil
+}
+
+// zoneFileLine is a logical line of a master file, with parenthesized continuations joined
+type zoneFileLine struct {
+	number     int
+	blankOwner bool
+	tokens     []zoneFileToken
+}
+
+// zoneFileToken is a word or a quoted character-string of a master file
+type zoneFileToken struct {
+	text   string
+	quoted bool
+}
+
+func (l zoneFileLine) errorf(format string, v ...interface{}) error {
+	return fmt.Errorf("zone file line %d: %s", l.number, fmt.Sprintf(format, v...))
+}
+
+// zoneFileLines splits a master file into logical lines of tokens, dropping comments and empty lines
+func zoneFileLines(r io.Reader) ([]zoneFileLine, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	src := string(b)
+
+	lines := []zoneFileLine{}
+	cur := zoneFileLine{number: 1}
+	number, depth := 1, 0
+	startOfLine := true
+	end := func() {
+		if len(cur.tokens) > 0 {
+			lines = append(lines, cur)
+		}
+		cur = zoneFileLine{number: number}
+		startOfLine = true
+	}
+
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\n':
+			number++
+			if depth == 0 {
+				end()
+			}
+		case c == ' ' || c == '\t' || c == '\r':
+			if startOfLine && len(cur.tokens) == 0 {
+				cur.blankOwner = true
+			}
+		case c == ';':
+			for i+1 < len(src) && src[i+1] != '\n' {
+				i++
+			}
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth == 0 {
+				return nil, fmt.Errorf("zone file line %d: unbalanced )", number)
+			}
+			depth--
+		case c == '"':
+			j := i + 1
+			for ; j < len(src) && src[j] != '"'; j++ {
+				if src[j] == '\\' {
+					j++
+				}
+				if j < len(src) && src[j] == '\n' {
+					number++
+				}
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("zone file line %d: unterminated quoted string", number)
+			}
+			cur.tokens = append(cur.tokens, zoneFileToken{text: src[i : j+1], quoted: true})
+			i = j
+		default:
+			j := i
+			for ; j < len(src) && !strings.ContainsRune(" \t\r\n;()\"", rune(src[j])); j++ {
+				if src[j] == '\\' {
+					j++
+				}
+			}
+			if j > len(src) {
+				j = len(src)
+			}
+			cur.tokens = append(cur.tokens, zoneFileToken{text: src[i:j]})
+			i = j - 1
+		}
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			startOfLine = false
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("zone file line %d: unbalanced (", number)
+	}
+	end()
+	return lines, nil
+}
+
+// parseTTL parses a TTL in seconds, or with BIND's w, d, h, m and s units such as "1h30m"
+func parseTTL(s string) (int, bool) {
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return 0, false
+	}
+	total, n := 0, 0
+	digits := false
+	for _, c := range strings.ToLower(s) {
+		switch {
+		case c >= '0' && c <= '9':
+			n = n*10 + int(c-'0')
+			digits = true
+			continue
+		case !digits:
+			return 0, false
+		case c == 'w':
+			n *= 7 * 24 * 3600
+		case c == 'd':
+			n *= 24 * 3600
+		case c == 'h':
+			n *= 3600
+		case c == 'm':
+			n *= 60
+		case c == 's':
+		default:
+			return 0, false
+		}
+		total += n
+		n, digits = 0, false
+	}
+	return total + n, true
+}
+
+// domainNameFields lists the rdata fields holding domain names, by record type
+var domainNameFields = map[string][]int{
+	"NS":    {0},
+	"CNAME": {0},
+	"DNAME": {0},
+	"PTR":   {0},
+	"MX":    {1},
+	"SRV":   {3},
+	"SOA":   {0, 1},
+}
+
+// parseRData joins rdata tokens with single spaces, qualifying domain names. TXT and SPF rdata
+// of a single character-string is unquoted; several character-strings are kept quoted, as the
+// API carries multi-string text, unless all but the last are 255 bytes long, as formatRData
+// splits long strings.
+func parseRData(typ string, toks []zoneFileToken, origin string) string {
+	if typ == "TXT" || typ == "SPF" {
+		strs := make([]string, len(toks))
+		split := true
+		for i, t := range toks {
+			strs[i] = unquoteCharacterString(t.text)
+			if i < len(toks)-1 && len(strs[i]) != 255 {
+				split = false
+			}
+		}
+		if split {
+			return strings.Join(strs, "")
+		}
+		for i := range strs {
+			strs[i] = quoteCharacterString(strs[i])
+		}
+		return strings.Join(strs, " ")
+	}
+
+	fields := make([]string, len(toks))
+	for i, t := range toks {
+		fields[i] = t.text
+	}
+	for _, i := range domainNameFields[typ] {
+		if i < len(fields) {
+			fields[i] = absoluteName(fields[i], origin)
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// unquoteCharacterString returns the text of an RFC 1035 character-string, quoted or not
+func unquoteCharacterString(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 == len(s) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		if i+2 < len(s) && isDigit(s[i]) && isDigit(s[i+1]) && isDigit(s[i+2]) {
+			b.WriteByte(byte(int(s[i]-'0')*100 + int(s[i+1]-'0')*10 + int(s[i+2]-'0')))
+			i += 2
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// absoluteName qualifies name with origin, "@" being origin itself
+func absoluteName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return name
+	}
+	return name + "." + origin
+}
+
+// containsString reports whether ss contains s
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
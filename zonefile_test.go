@@ -0,0 +1,234 @@
+package udnssdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testZoneFileRRSets = []RRSet{
+	{OwnerName: "www.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"192.0.2.1", "192.0.2.2"}},
+	{OwnerName: "example.com.", RRType: "NS (2)", TTL: 86400, RData: []string{"pdns1.ultradns.net.", "pdns2.ultradns.net."}},
+	{OwnerName: "example.com.", RRType: "TXT (16)", TTL: 3600, RData: []string{`v=spf1 include:"_spf.example.net" -all`}},
+	{OwnerName: "example.com.", RRType: "SOA (6)", TTL: 86400, RData: []string{"pdns1.ultradns.net. admin.example.com. 2024010101 86400 86400 86400 86400"}},
+	{OwnerName: "alias.example.org.", RRType: "CNAME (5)", TTL: 300, RData: []string{"www.example.com."}},
+	{
+		OwnerName: "pool.example.com.",
+		RRType:    "A (1)",
+		TTL:       60,
+		RData:     []string{"192.0.2.10", "192.0.2.11"},
+		Profile:   RawProfile{"@context": string(RDPoolSchema), "order": "ROUND_ROBIN", "description": "web\npool"},
+	},
+}
+
+func Test_WriteZoneFile(t *testing.T) {
+	want := `; zone example.com. exported by udnssdk
+$ORIGIN example.com.
+$TTL 86400
+@                  86400 IN SOA   pdns1.ultradns.net. admin.example.com. 2024010101 86400 86400 86400 86400
+@                  86400 IN NS    pdns1.ultradns.net.
+@                  86400 IN NS    pdns2.ultradns.net.
+@                  3600  IN TXT   "v=spf1 include:\"_spf.example.net\" -all"
+alias.example.org. 300   IN CNAME www.example.com.
+
+; @context: http://schemas.ultradns.com/RDPool.jsonschema
+; description: web pool
+pool 60  IN A 192.0.2.10
+pool 60  IN A 192.0.2.11
+www  300 IN A 192.0.2.1
+www  300 IN A 192.0.2.2
+`
+	var buf bytes.Buffer
+	if err := WriteZoneFile(&buf, "example.com", testZoneFileRRSets); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want {
+		t.Errorf("WriteZoneFile:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func Test_formatRData_TXT(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	cases := []struct {
+		rdata string
+		want  string
+	}{
+		{"hello world", `"hello world"`},
+		{`"already" "quoted"`, `"already" "quoted"`},
+		{"", `""`},
+		{"tab\there\\", `"tab\009here\\"`},
+		{long, fmt.Sprintf(`"%s" "%s"`, long[:255], long[255:])},
+	}
+	for _, c := range cases {
+		if got := formatRData("TXT", c.rdata); got != c.want {
+			t.Errorf("formatRData(%q): %s, want: %s", c.rdata, got, c.want)
+		}
+	}
+	if got := formatRData("MX", "10 mail.example.com."); got != "10 mail.example.com." {
+		t.Errorf("formatRData(MX): %s", got)
+	}
+}
+
+func Test_relativeName(t *testing.T) {
+	cases := map[string]string{
+		"example.com.":     "@",
+		"www.example.com.": "www",
+		"a.b.Example.COM.": "a.b",
+		"www.example.net.": "www.example.net.",
+		"badexample.com.":  "badexample.com.",
+		"relative":         "relative",
+		"":                 "@",
+	}
+	for owner, want := range cases {
+		if got := relativeName(owner, "example.com."); got != want {
+			t.Errorf("relativeName(%q): %q, want: %q", owner, got, want)
+		}
+	}
+}
+
+func Test_RRSets_Export(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/zones/example.com./rrsets/ANY" {
+			t.Errorf("path: %q", r.URL.Path)
+		}
+		resp := RRSetListDTO{
+			ZoneName:   "example.com.",
+			Rrsets:     testZoneFileRRSets[:2],
+			Resultinfo: ResultInfo{TotalCount: 2, ReturnedCount: 2},
+		}
+		mess, _ := json.Marshal(resp)
+		fmt.Fprintln(w, string(mess))
+	}))
+	defer ts.Close()
+
+	testClient, _ := newStubClient(testUsername, testPassword, ts.URL, "", "")
+
+	var buf bytes.Buffer
+	if err := testClient.RRSets.Export(&buf, "example.com."); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"$ORIGIN example.com.\n", "www 300   IN A  192.0.2.1\n", "@   86400 IN NS pdns1.ultradns.net.\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Export does not contain %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func Test_ParseZoneFile(t *testing.T) {
+	src := `; migrated from elsewhere
+$TTL 1h
+@	IN	SOA	ns1.other.net. hostmaster ( 2024010101 ; serial
+		3600 600 86400 300 )
+	IN	NS	ns1.other.net.
+	IN	NS	ns2.other.net.
+@	300	IN	MX	10 mail
+www	300	IN	A	192.0.2.1
+	IN	300	A	192.0.2.2
+www	IN	A	192.0.2.1 ; duplicate
+txt	TXT	"v=spf1 ; not a comment" "-all"
+esc	TXT	"say \"hi\"\009"
+$ORIGIN sub.example.com.
+host	1d	AAAA	2001:db8::1
+alias	CNAME	host
+ext.example.net.	60	CNAME	www.example.com.
+`
+	got, err := ParseZoneFile(strings.NewReader(src), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []RRSet{
+		{OwnerName: "example.com.", RRType: "SOA", TTL: 3600, RData: []string{"ns1.other.net. hostmaster.example.com. 2024010101 3600 600 86400 300"}},
+		{OwnerName: "example.com.", RRType: "NS", TTL: 3600, RData: []string{"ns1.other.net.", "ns2.other.net."}},
+		{OwnerName: "example.com.", RRType: "MX", TTL: 300, RData: []string{"10 mail.example.com."}},
+		{OwnerName: "www.example.com.", RRType: "A", TTL: 300, RData: []string{"192.0.2.1", "192.0.2.2"}},
+		{OwnerName: "txt.example.com.", RRType: "TXT", TTL: 3600, RData: []string{`"v=spf1 ; not a comment" "-all"`}},
+		{OwnerName: "esc.example.com.", RRType: "TXT", TTL: 3600, RData: []string{"say \"hi\"\t"}},
+		{OwnerName: "host.sub.example.com.", RRType: "AAAA", TTL: 86400, RData: []string{"2001:db8::1"}},
+		{OwnerName: "alias.sub.example.com.", RRType: "CNAME", TTL: 3600, RData: []string{"host.sub.example.com."}},
+		{OwnerName: "ext.example.net.", RRType: "CNAME", TTL: 60, RData: []string{"www.example.com."}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ParseZoneFile: %d RRSets, want: %d\n%+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("rrsets[%d]: %+v, want: %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func Test_ParseZoneFile_Errors(t *testing.T) {
+	cases := map[string]string{
+		"www A 192.0.2.1\n":             "no TTL",
+		"$TTL 60\n  A 192.0.2.1\n":      "without an owner",
+		"$TTL 60\nwww CH A 192.0.2.1\n": "unsupported class",
+		"$INCLUDE other.zone\n":         "unsupported directive",
+		"$TTL 60\nwww A (192.0.2.1\n":   "unbalanced (",
+		"$TTL 60\nwww TXT \"open\n":     "unterminated",
+		"$TTL 60\nwww 60 IN\n":          "missing record type",
+		"$TTL 60\nwww A\n":              "without rdata",
+	}
+	for src, want := range cases {
+		_, err := ParseZoneFile(strings.NewReader(src), "example.com.")
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseZoneFile(%q): %v, want error containing %q", src, err, want)
+		}
+	}
+}
+
+func Test_ZoneFile_RoundTrip(t *testing.T) {
+	long := strings.Repeat("x", 400)
+	rrsets := append(testZoneFileRRSets,
+		RRSet{OwnerName: "long.example.com.", RRType: "TXT (16)", TTL: 300, RData: []string{long}},
+		RRSet{OwnerName: "multi.example.com.", RRType: "TXT (16)", TTL: 300, RData: []string{`"a" "b"`, `"say \"hi\"" "two words"`}},
+	)
+
+	var buf bytes.Buffer
+	if err := WriteZoneFile(&buf, "example.com.", rrsets); err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseZoneFile(&buf, "example.com.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := DiffWithOptions("example.com.", rrsets, parsed, PlanOptions{KeepProfiles: true}); !p.Empty() {
+		t.Errorf("round trip changed the zone:\n%s", p)
+	}
+}
+
+func Test_parseTTL(t *testing.T) {
+	cases := map[string]int{"300": 300, "1h": 3600, "1h30m": 5400, "2d": 172800, "1w": 604800, "10S": 10}
+	for s, want := range cases {
+		if got, ok := parseTTL(s); !ok || got != want {
+			t.Errorf("parseTTL(%q): %d, %v, want: %d", s, got, ok, want)
+		}
+	}
+	for _, s := range []string{"", "IN", "h1", "1x"} {
+		if _, ok := parseTTL(s); ok {
+			t.Errorf("parseTTL(%q) succeeded", s)
+		}
+	}
+}
+
+func Test_ParseZoneFile_TXTStrings(t *testing.T) {
+	src := "$TTL 60\none TXT \"hello world\"\nwords TXT hello world\nquoted TXT \"a\" \"b\"\n"
+	rrsets, err := ParseZoneFile(strings.NewReader(src), "example.com.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"one.example.com.":    "hello world",
+		"words.example.com.":  `"hello" "world"`,
+		"quoted.example.com.": `"a" "b"`,
+	}
+	for _, rr := range rrsets {
+		if rr.RData[0] != want[rr.OwnerName] {
+			t.Errorf("%s: %q, want: %q", rr.OwnerName, rr.RData[0], want[rr.OwnerName])
+		}
+	}
+}